@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"github.com/smartcity/backend/internal/config"
+	applog "github.com/smartcity/backend/internal/log"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		applog.Default().Infof(context.Background(), "No .env file found, using system environment")
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		applog.Default().Errorf(context.Background(), "config: %v", err)
+		os.Exit(1)
+	}
+	applog.SetDefault(applog.New(os.Stdout, applog.ParseLevel(cfg.LogLevel)))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := Run(ctx, cfg); err != nil {
+		applog.Default().Errorf(context.Background(), "server: %v", err)
+		os.Exit(1)
+	}
+}
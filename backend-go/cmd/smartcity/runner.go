@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+
+	"github.com/smartcity/backend/internal/alerts"
+	"github.com/smartcity/backend/internal/config"
+	"github.com/smartcity/backend/internal/database"
+	"github.com/smartcity/backend/internal/delivery/http"
+	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/httpcache"
+	"github.com/smartcity/backend/internal/ingest/mqtt"
+	applog "github.com/smartcity/backend/internal/log"
+	"github.com/smartcity/backend/internal/repository"
+	"github.com/smartcity/backend/internal/repository/influxdb"
+	"github.com/smartcity/backend/internal/repository/postgres"
+	"github.com/smartcity/backend/internal/service"
+)
+
+// Run wires up every dependency, starts the Fiber server, and blocks until
+// ctx is cancelled (typically by an OS signal — see main.go — but a test can
+// cancel it directly to boot the whole app in-process against a
+// testcontainers Postgres and then shut it down deterministically). It
+// returns nil on a clean shutdown, or the error that made startup fail.
+func Run(ctx context.Context, cfg *config.Config) error {
+	log := applog.Default()
+
+	// Database connection: retried with backoff since a slow-starting
+	// database (e.g. racing the backend in docker-compose) shouldn't
+	// permanently strand the backend in mock mode; a truly unavailable one
+	// still degrades to mock data rather than failing startup.
+	pool, err := database.NewPool(ctx, database.Config{DatabaseURL: cfg.DatabaseURL})
+	if err != nil {
+		log.Warnf(ctx, "database: giving up after retries, running with mock data only: %v", err)
+		pool = nil
+	} else {
+		defer pool.Close()
+		log.Infof(ctx, "database: connected to PostgreSQL")
+	}
+
+	// Dependency Injection: Repositories. DATA_BACKEND selects where
+	// weather/traffic telemetry and prediction logs are persisted:
+	//   postgres (default) - everything in PostgreSQL, as before
+	//   influxdb           - everything in InfluxDB
+	//   hybrid             - telemetry in InfluxDB (downsampling/retention),
+	//                        prediction logs in PostgreSQL (relational)
+	var dataRepo service.DataRepository
+	postgresRepo := func() service.DataRepository {
+		if pool != nil {
+			return postgres.NewPostgresRepository(pool)
+		}
+		return postgres.NewMockRepository()
+	}
+
+	switch cfg.DataBackend {
+	case "influxdb":
+		influxRepo := influxdb.NewInfluxRepository(cfg.InfluxURL, cfg.InfluxToken, cfg.InfluxOrg, cfg.InfluxBucket)
+		defer influxRepo.Close()
+		dataRepo = influxRepo
+	case "hybrid":
+		influxRepo := influxdb.NewInfluxRepository(cfg.InfluxURL, cfg.InfluxToken, cfg.InfluxOrg, cfg.InfluxBucket)
+		defer influxRepo.Close()
+		dataRepo = repository.NewHybridRepository(influxRepo, postgresRepo())
+	default:
+		dataRepo = postgresRepo()
+	}
+
+	// Traffic history is always backed by Postgres (per-road bucketed data
+	// doesn't fit DATA_BACKEND's weather/traffic telemetry split above), with
+	// the same mock fallback as postgresRepo() when there's no database.
+	var trafficHistoryRepo domain.TrafficHistoryRepository
+	if pool != nil {
+		trafficHistoryRepo = postgres.NewTrafficHistoryRepository(pool)
+	} else {
+		trafficHistoryRepo = postgres.NewMockTrafficHistoryRepository()
+	}
+	trafficHistorySvc := service.NewTrafficHistoryService(trafficHistoryRepo)
+
+	// Alert rules are optional: a missing/invalid file just means no rules,
+	// not a startup failure, since the dashboard is useful without alerting.
+	alertRules, err := alerts.LoadRules(cfg.AlertsRulesPath)
+	if err != nil {
+		log.Warnf(ctx, "alerts: could not load rules from %s: %v", cfg.AlertsRulesPath, err)
+	}
+	alertSinks := buildAlertSinks(cfg)
+	alertEngine := alerts.NewEngine(alertRules, alertSinks...)
+
+	// Re-reading the rules file on SIGHUP lets operators tune thresholds
+	// (e.g. the AQI alert during a winter inversion) without a restart.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			rules, err := alerts.LoadRules(cfg.AlertsRulesPath)
+			if err != nil {
+				log.Warnf(ctx, "alerts: SIGHUP reload failed, keeping previous rules: %v", err)
+				continue
+			}
+			alertEngine.Reload(rules)
+			log.Infof(ctx, "alerts: reloaded %d rules from %s", len(rules), cfg.AlertsRulesPath)
+		}
+	}()
+
+	// HTTP cache: persisted to disk via Bolt when possible so the
+	// last-known-good upstream response survives a restart, falling back to
+	// an in-memory-only cache (no persistence, no conditional-request history)
+	// if the file can't be opened.
+	var cacheStore httpcache.Store
+	boltStore, err := httpcache.NewBoltStore(cfg.HTTPCachePath)
+	if err != nil {
+		log.Warnf(ctx, "httpcache: could not open persistent cache at %s, using in-memory only: %v", cfg.HTTPCachePath, err)
+		cacheStore = httpcache.NewMemoryStore(256)
+	} else {
+		defer boltStore.Close()
+		cacheStore = boltStore
+	}
+
+	// MQTT ingestion is optional: city sensors publishing telemetry augment
+	// the single external weather/traffic API, but aren't required to run.
+	var mqttIngestor *mqtt.Ingestor
+	if cfg.MQTTBrokerURL != "" {
+		mqttIngestor = mqtt.NewIngestor(mqtt.Config{
+			BrokerURL: cfg.MQTTBrokerURL,
+			ClientID:  cfg.MQTTClientID,
+			Username:  cfg.MQTTUsername,
+			Password:  cfg.MQTTPassword,
+		}, dataRepo)
+		mqttCtx, mqttCancel := context.WithTimeout(ctx, 10*time.Second)
+		err := mqttIngestor.Start(mqttCtx)
+		mqttCancel()
+		if err != nil {
+			log.Warnf(ctx, "mqtt: could not connect to broker %s: %v", cfg.MQTTBrokerURL, err)
+			mqttIngestor = nil
+		} else {
+			log.Infof(ctx, "mqtt: connected to broker %s", cfg.MQTTBrokerURL)
+		}
+	}
+
+	// Weather provider chain is optional: a missing/invalid config file just
+	// means the single default Open-Meteo provider, not a startup failure.
+	weatherCfg, err := service.LoadWeatherServiceConfig(cfg.WeatherConfigPath)
+	if err != nil {
+		log.Warnf(ctx, "weather: could not load provider config from %s, using Open-Meteo only: %v", cfg.WeatherConfigPath, err)
+	}
+
+	// Dependency Injection: Services
+	weatherSvc, err := service.NewWeatherServiceFromConfig(weatherCfg, cacheStore)
+	if err != nil {
+		return fmt.Errorf("weather: invalid provider config in %s: %w", cfg.WeatherConfigPath, err)
+	}
+	// Traffic provider chain: TomTom, HERE and Yandex each contribute flow
+	// and incidents if their API key is configured; TrafficService merges
+	// whichever of them succeed instead of relying on a single vendor's
+	// 2,500/day free tier.
+	trafficHTTPClient := &nethttp.Client{Timeout: 15 * time.Second}
+	var trafficProviders []service.TrafficProvider
+	if cfg.TomTomAPIKey != "" {
+		trafficProviders = append(trafficProviders, service.NewTomTomTrafficProvider(cfg.TomTomAPIKey, trafficHTTPClient))
+	}
+	if cfg.HereAPIKey != "" {
+		trafficProviders = append(trafficProviders, service.NewHereTrafficProvider(cfg.HereAPIKey, trafficHTTPClient))
+	}
+	if cfg.YandexAPIKey != "" {
+		trafficProviders = append(trafficProviders, service.NewYandexTrafficProvider(cfg.YandexAPIKey, trafficHTTPClient))
+	}
+	trafficSvc := service.NewTrafficService(trafficProviders...)
+	transitSvc := service.NewTransitService(service.TransitFeedConfig{
+		VehiclePositionsURL: cfg.TransitVehiclePositionsURL,
+		TripUpdatesURL:      cfg.TransitTripUpdatesURL,
+		ServiceAlertsURL:    cfg.TransitServiceAlertsURL,
+	})
+	mlBridge := service.NewMLBridge(cfg.MLServiceURL, cacheStore)
+	dashboardSvc := service.NewDashboardService(weatherSvc, trafficSvc, transitSvc, dataRepo, alertEngine)
+
+	// Prediction falls back from the Python ML service to a local regression
+	// to a canned mock, in that order, so an ML service outage degrades
+	// Predict instead of failing it outright.
+	localPredictor := service.NewLocalPredictor(cfg.LocalPredictorWeightsPath)
+	predictor := service.NewCompositePredictor(mlBridge, localPredictor, service.NewMockPredictor())
+
+	trainerCtx, stopTrainer := context.WithCancel(ctx)
+	defer stopTrainer()
+	go localPredictor.RunBackgroundTrainer(
+		trainerCtx, dataRepo,
+		time.Duration(cfg.LocalPredictorRetrainMinutes)*time.Minute,
+		30*24*time.Hour,
+	)
+
+	// Predictions are expensive (the ML service call dominates request
+	// latency), so an LRU in front of the predictor chain serves repeat
+	// (date, query, live-data) combinations without recomputing.
+	predictionCache := service.NewPredictionCache(
+		predictor,
+		cfg.PredictionCacheCapacity,
+		time.Duration(cfg.PredictionCacheTTLMinutes)*time.Minute,
+		cfg.PredictionCacheSnapshotPath,
+	)
+
+	// Broker fans dashboard snapshots out to SSE/WebSocket subscribers on a
+	// single shared ticker instead of one upstream fetch per client.
+	broker := service.NewBroker(dashboardSvc, 30*time.Second)
+	brokerCtx, stopBroker := context.WithCancel(ctx)
+	defer stopBroker()
+	go broker.Run(brokerCtx)
+
+	// Periodically snapshots TrafficService's merged congestion into
+	// trafficHistoryRepo so /history/traffic/buckets and
+	// /history/traffic/typical have data to serve.
+	trafficHistoryCtx, stopTrafficHistory := context.WithCancel(ctx)
+	defer stopTrafficHistory()
+	go trafficHistorySvc.Run(trafficHistoryCtx, trafficSvc, time.Duration(cfg.TrafficHistoryIntervalMinutes)*time.Minute)
+
+	// Fiber App
+	app := fiber.New(fiber.Config{
+		AppName:      "SmartCity API v1.0",
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		ErrorHandler: customErrorHandler,
+	})
+
+	// Middleware
+	app.Use(recover.New())
+	app.Use(http.RequestLogMiddleware)
+	app.Use(cors.New(cors.Config{
+		AllowOrigins: "*",
+		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
+		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+	}))
+	app.Use(http.LanguageMiddleware)
+
+	// Routes
+	http.SetupRoutes(app, dashboardSvc, mlBridge, predictionCache, dataRepo, broker, trafficHistorySvc)
+
+	// Graceful shutdown
+	serveErr := make(chan error, 1)
+	go func() {
+		port := cfg.Port
+		if port == "" {
+			port = "8080"
+		}
+		log.Infof(ctx, "server: starting on :%s", port)
+		if err := app.Listen(":" + port); err != nil {
+			serveErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Infof(context.Background(), "server: shutting down")
+	if err := app.ShutdownWithTimeout(5 * time.Second); err != nil {
+		log.Warnf(context.Background(), "server: forced to shutdown: %v", err)
+	}
+	if mqttIngestor != nil {
+		mqttIngestor.Stop()
+	}
+	signal.Stop(reload)
+	close(reload)
+	// stopTrainer/stopBroker/stopTrafficHistory are deferred above so they
+	// also fire on the early-return path if app.Listen fails; broker.Shutdown
+	// still needs to be called explicitly here since it's not a CancelFunc.
+	broker.Shutdown()
+	dashboardSvc.WaitBackground()
+	if err := predictionCache.Snapshot(); err != nil {
+		log.Warnf(context.Background(), "prediction cache: could not snapshot: %v", err)
+	}
+	log.Infof(context.Background(), "server: exited gracefully")
+
+	return nil
+}
+
+// buildAlertSinks constructs every alert sink with a non-empty configuration,
+// so operators opt in per-channel just by setting its env vars.
+func buildAlertSinks(cfg *config.Config) []alerts.Sink {
+	var sinks []alerts.Sink
+
+	if cfg.AlertWebhookURL != "" {
+		sinks = append(sinks, alerts.NewWebhookSink(cfg.AlertWebhookURL))
+	}
+	if cfg.AlertTelegramBot != "" && cfg.AlertTelegramChat != "" {
+		sinks = append(sinks, alerts.NewTelegramSink(cfg.AlertTelegramBot, cfg.AlertTelegramChat))
+	}
+	if cfg.AlertSMTPHost != "" && cfg.AlertEmailFrom != "" && cfg.AlertEmailTo != "" {
+		sinks = append(sinks, alerts.NewEmailSink(
+			cfg.AlertSMTPHost, cfg.AlertSMTPPort, cfg.AlertSMTPUser, cfg.AlertSMTPPassword,
+			cfg.AlertEmailFrom, strings.Split(cfg.AlertEmailTo, ","),
+		))
+	}
+
+	return sinks
+}
+
+func customErrorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	message := "Internal Server Error"
+
+	if e, ok := err.(*fiber.Error); ok {
+		code = e.Code
+		message = e.Message
+	}
+
+	return c.Status(code).JSON(fiber.Map{
+		"error":   true,
+		"message": message,
+	})
+}
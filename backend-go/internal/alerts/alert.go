@@ -0,0 +1,26 @@
+package alerts
+
+import "time"
+
+// Status is a rule's lifecycle state. Rules start Resolved, move to Pending
+// once their condition is met, and only become Firing after the condition
+// has held continuously for the rule's For duration (hysteresis on the
+// rising edge avoids notifying on a single noisy reading).
+type Status string
+
+const (
+	StatusResolved Status = "resolved"
+	StatusPending  Status = "pending"
+	StatusFiring   Status = "firing"
+)
+
+// Alert is one rule transition (Pending→Firing or Firing→Resolved) dispatched
+// to the configured sinks. Pending transitions are not dispatched — only the
+// edges a human or webhook consumer actually cares about.
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Status    Status    `json:"status"`
+	Severity  string    `json:"severity,omitempty"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
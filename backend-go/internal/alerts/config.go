@@ -0,0 +1,36 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rulesFile is the on-disk shape of the alert rules YAML, e.g.:
+//
+//	rules:
+//	  - name: smog-alert
+//	    metric: aqi
+//	    operator: ">"
+//	    threshold: 150
+//	    for: 30m
+//	    severity: critical
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadRules parses the alert rule set from a YAML file at path.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("alerts: failed to read rules file %s: %w", path, err)
+	}
+
+	var parsed rulesFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("alerts: failed to parse rules file %s: %w", path, err)
+	}
+
+	return parsed.Rules, nil
+}
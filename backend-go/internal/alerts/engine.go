@@ -0,0 +1,141 @@
+package alerts
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// historySize bounds the in-memory ring buffer backing GET /api/v1/alerts.
+const historySize = 100
+
+// ruleState is the runtime evaluation state for a single rule, keyed by
+// Rule.Name in Engine.state.
+type ruleState struct {
+	status       Status
+	conditionMet bool
+	since        time.Time // when conditionMet last flipped
+}
+
+// Engine evaluates every configured Rule against each new dashboard snapshot
+// and dispatches Alerts to sinks on Pending→Firing and Firing→Resolved
+// transitions. It is safe for concurrent use.
+type Engine struct {
+	mu    sync.Mutex
+	rules []Rule
+	state map[string]*ruleState
+	sinks []Sink
+
+	history *MemorySink
+	wgBg    sync.WaitGroup
+}
+
+// NewEngine creates an Engine for the given rules, dispatching firing/resolved
+// alerts to sinks in addition to the always-present in-memory history buffer.
+func NewEngine(rules []Rule, sinks ...Sink) *Engine {
+	return &Engine{
+		rules:   rules,
+		state:   make(map[string]*ruleState),
+		sinks:   sinks,
+		history: NewMemorySink(historySize),
+	}
+}
+
+// Evaluate checks every rule against data and returns the alerts it
+// dispatched, firing or resolved (if any). Sink dispatch happens in the background so a slow webhook/SMTP
+// sink never blocks the caller (typically DashboardService.GetDashboardData).
+func (e *Engine) Evaluate(data domain.DashboardData) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	var fired []Alert
+	for i := range e.rules {
+		rule := &e.rules[i]
+		st, ok := e.state[rule.Name]
+		if !ok {
+			st = &ruleState{status: StatusResolved, since: now}
+			e.state[rule.Name] = st
+		}
+
+		met, detail := rule.evaluate(data)
+		if met != st.conditionMet {
+			st.conditionMet = met
+			st.since = now
+		}
+		sustained := now.Sub(st.since) >= time.Duration(rule.For)
+
+		var alert *Alert
+		switch {
+		case met && sustained && st.status != StatusFiring:
+			st.status = StatusFiring
+			alert = &Alert{Rule: rule.Name, Status: StatusFiring, Severity: rule.Severity, Message: detail, Timestamp: now}
+		case met && !sustained && st.status == StatusResolved:
+			st.status = StatusPending
+		case !met && st.status == StatusFiring && sustained:
+			st.status = StatusResolved
+			alert = &Alert{Rule: rule.Name, Status: StatusResolved, Severity: rule.Severity, Message: rule.Name + " cleared", Timestamp: now}
+		case !met && st.status == StatusPending:
+			st.status = StatusResolved
+		}
+
+		if alert != nil {
+			fired = append(fired, *alert)
+			e.dispatch(*alert)
+		}
+	}
+
+	return fired
+}
+
+// dispatch fans an alert out to every sink asynchronously, logging (not
+// returning) errors since sink failures shouldn't affect rule evaluation.
+func (e *Engine) dispatch(alert Alert) {
+	_ = e.history.Send(context.Background(), alert)
+
+	for _, sink := range e.sinks {
+		sink := sink
+		e.wgBg.Add(1)
+		go func() {
+			defer e.wgBg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := sink.Send(ctx, alert); err != nil {
+				log.Printf("alerts: %s sink failed: %v", sink.Name(), err)
+			}
+		}()
+	}
+}
+
+// History returns the most recent alerts, newest last, for GET /api/v1/alerts.
+func (e *Engine) History() []Alert {
+	return e.history.List()
+}
+
+// WaitBackground blocks until all in-flight sink dispatches complete. Call
+// during graceful shutdown to avoid dropped notifications.
+func (e *Engine) WaitBackground() {
+	e.wgBg.Wait()
+}
+
+// Reload replaces the rule set (e.g. on SIGHUP), carrying over existing
+// runtime state for rules that are still present by name so an in-progress
+// Pending/Firing rule isn't reset to Resolved by an unrelated config edit.
+func (e *Engine) Reload(rules []Rule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	newState := make(map[string]*ruleState, len(rules))
+	for _, r := range rules {
+		if st, ok := e.state[r.Name]; ok {
+			newState[r.Name] = st
+		} else {
+			newState[r.Name] = &ruleState{status: StatusResolved, since: time.Now()}
+		}
+	}
+	e.rules = rules
+	e.state = newState
+}
@@ -0,0 +1,104 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/pkg/utils"
+)
+
+// Duration wraps time.Duration so rules can write human-readable YAML like
+// `for: 30m` instead of a raw nanosecond count.
+type Duration time.Duration
+
+// UnmarshalYAML parses a Go duration string ("30m", "2h") from YAML.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("alerts: invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Rule is one user-defined alerting condition, loaded from YAML. Metric
+// selects which field of a DashboardData snapshot is compared:
+//
+//	aqi               - Weather.AQI
+//	temperature       - Weather.Temperature
+//	congestion_index  - Traffic.CongestionIndex
+//	incident          - any Traffic.Incident of IncidentType within RadiusKm
+//	                    of (Latitude, Longitude)
+//
+// For the first three, Operator/Threshold are required; for "incident",
+// RadiusKm/Latitude/Longitude are required and IncidentType is optional
+// (empty matches any type).
+type Rule struct {
+	Name      string   `yaml:"name"`
+	Metric    string   `yaml:"metric"`
+	Operator  string   `yaml:"operator,omitempty"`
+	Threshold float64  `yaml:"threshold,omitempty"`
+	For       Duration `yaml:"for,omitempty"`
+	Severity  string   `yaml:"severity,omitempty"`
+
+	IncidentType string  `yaml:"incident_type,omitempty"`
+	Latitude     float64 `yaml:"lat,omitempty"`
+	Longitude    float64 `yaml:"lon,omitempty"`
+	RadiusKm     float64 `yaml:"radius_km,omitempty"`
+}
+
+// evaluate reports whether the rule's condition currently holds against
+// data, along with a human-readable explanation for the resulting Alert.
+func (r *Rule) evaluate(data domain.DashboardData) (met bool, detail string) {
+	switch r.Metric {
+	case "aqi":
+		aqi := float64(data.Weather.AQI)
+		return compare(aqi, r.Operator, r.Threshold),
+			fmt.Sprintf("AQI is %.0f (threshold %s %.0f)", aqi, r.Operator, r.Threshold)
+	case "temperature":
+		temp := data.Weather.Temperature
+		return compare(temp, r.Operator, r.Threshold),
+			fmt.Sprintf("temperature is %.1f°C (threshold %s %.1f)", temp, r.Operator, r.Threshold)
+	case "congestion_index":
+		idx := data.Traffic.CongestionIndex
+		return compare(idx, r.Operator, r.Threshold),
+			fmt.Sprintf("congestion index is %.2f (threshold %s %.2f)", idx, r.Operator, r.Threshold)
+	case "incident":
+		for _, inc := range data.Traffic.Incidents {
+			if r.IncidentType != "" && inc.Type != r.IncidentType {
+				continue
+			}
+			if utils.Haversine(r.Latitude, r.Longitude, inc.Latitude, inc.Longitude) <= r.RadiusKm {
+				return true, fmt.Sprintf("%s incident within %.1fkm of (%.4f, %.4f)", inc.Type, r.RadiusKm, r.Latitude, r.Longitude)
+			}
+		}
+		return false, ""
+	default:
+		return false, ""
+	}
+}
+
+// compare applies a rule's comparison operator to a metric value.
+func compare(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	default:
+		return false
+	}
+}
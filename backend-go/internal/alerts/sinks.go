@@ -0,0 +1,173 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// defaultSinkTimeout bounds how long a single HTTP-based sink delivery may
+// take before it's abandoned.
+const defaultSinkTimeout = 10 * time.Second
+
+// Sink delivers a fired/resolved Alert to an external notification channel.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, alert Alert) error
+}
+
+// WebhookSink POSTs each alert as JSON to a configured URL.
+type WebhookSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink posting to targetURL.
+func NewWebhookSink(targetURL string) *WebhookSink {
+	return &WebhookSink{
+		url:        targetURL,
+		httpClient: &http.Client{Timeout: defaultSinkTimeout},
+	}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("webhook sink: failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook sink: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook sink: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook sink: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TelegramSink sends each alert as a message via a Telegram bot.
+type TelegramSink struct {
+	botToken   string
+	chatID     string
+	httpClient *http.Client
+}
+
+// NewTelegramSink creates a TelegramSink posting to chatID via botToken.
+func NewTelegramSink(botToken, chatID string) *TelegramSink {
+	return &TelegramSink{
+		botToken:   botToken,
+		chatID:     chatID,
+		httpClient: &http.Client{Timeout: defaultSinkTimeout},
+	}
+}
+
+func (s *TelegramSink) Name() string { return "telegram" }
+
+func (s *TelegramSink) Send(ctx context.Context, alert Alert) error {
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", s.botToken)
+	text := fmt.Sprintf("[%s] %s: %s", alert.Status, alert.Rule, alert.Message)
+
+	form := url.Values{
+		"chat_id": {s.chatID},
+		"text":    {text},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("telegram sink: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("telegram sink: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram sink: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailSink delivers each alert as a plaintext email over SMTP.
+type EmailSink struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewEmailSink creates an EmailSink authenticating to host:port with
+// username/password, sending mail from from to each address in to.
+func NewEmailSink(host string, port int, username, password, from string, to []string) *EmailSink {
+	return &EmailSink{host: host, port: port, username: username, password: password, from: from, to: to}
+}
+
+func (s *EmailSink) Name() string { return "email" }
+
+func (s *EmailSink) Send(_ context.Context, alert Alert) error {
+	subject := fmt.Sprintf("[%s] SmartCity alert: %s", alert.Status, alert.Rule)
+	msg := []byte(fmt.Sprintf("Subject: %s\r\n\r\n%s\r\n", subject, alert.Message))
+
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+	if err := smtp.SendMail(addr, auth, s.from, s.to, msg); err != nil {
+		return fmt.Errorf("email sink: send failed: %w", err)
+	}
+	return nil
+}
+
+// MemorySink keeps the most recent alerts in a fixed-size ring buffer,
+// always wired into Engine so GET /api/v1/alerts has something to return
+// even with no external sinks configured.
+type MemorySink struct {
+	mu       sync.Mutex
+	buf      []Alert
+	capacity int
+}
+
+// NewMemorySink creates a MemorySink retaining up to capacity alerts.
+func NewMemorySink(capacity int) *MemorySink {
+	return &MemorySink{capacity: capacity}
+}
+
+func (s *MemorySink) Name() string { return "memory" }
+
+func (s *MemorySink) Send(_ context.Context, alert Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf = append(s.buf, alert)
+	if len(s.buf) > s.capacity {
+		s.buf = s.buf[len(s.buf)-s.capacity:]
+	}
+	return nil
+}
+
+// List returns a copy of the buffered alerts, oldest first.
+func (s *MemorySink) List() []Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Alert, len(s.buf))
+	copy(out, s.buf)
+	return out
+}
@@ -0,0 +1,169 @@
+// Package config loads and validates the backend's environment-driven
+// configuration. It has no dependencies on the service/repository packages
+// it configures, so it can be unit-tested (and reasoned about) in isolation.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config holds every environment-driven setting the backend needs to start.
+type Config struct {
+	DatabaseURL  string
+	TomTomAPIKey string
+	HereAPIKey   string
+	YandexAPIKey string
+	MLServiceURL string
+	Port         string
+	Env          string
+	LogLevel     string
+
+	WeatherConfigPath string
+
+	AlertsRulesPath   string
+	AlertWebhookURL   string
+	AlertTelegramBot  string
+	AlertTelegramChat string
+	AlertSMTPHost     string
+	AlertSMTPPort     int
+	AlertSMTPUser     string
+	AlertSMTPPassword string
+	AlertEmailFrom    string
+	AlertEmailTo      string
+
+	HTTPCachePath string
+
+	DataBackend  string
+	InfluxURL    string
+	InfluxToken  string
+	InfluxOrg    string
+	InfluxBucket string
+
+	MQTTBrokerURL string
+	MQTTClientID  string
+	MQTTUsername  string
+	MQTTPassword  string
+
+	LocalPredictorWeightsPath    string
+	LocalPredictorRetrainMinutes int
+
+	PredictionCacheCapacity     int
+	PredictionCacheTTLMinutes   int
+	PredictionCacheSnapshotPath string
+
+	TransitVehiclePositionsURL string
+	TransitTripUpdatesURL      string
+	TransitServiceAlertsURL    string
+
+	TrafficHistoryIntervalMinutes int
+}
+
+// LoadFromEnv builds a Config from environment variables (see getEnv/getEnvInt
+// for the defaults applied when a variable is unset), then validates it.
+func LoadFromEnv() (*Config, error) {
+	cfg := &Config{
+		DatabaseURL:  getEnv("DATABASE_URL", ""),
+		TomTomAPIKey: getEnv("TOMTOM_API_KEY", ""),
+		HereAPIKey:   getEnv("HERE_API_KEY", ""),
+		YandexAPIKey: getEnv("YANDEX_API_KEY", ""),
+		MLServiceURL: getEnv("ML_SERVICE_URL", "http://localhost:8000"),
+		Port:         getEnv("PORT", "8080"),
+		Env:          getEnv("GO_ENV", "development"),
+		LogLevel:     getEnv("GO_LOG_LEVEL", "info"),
+
+		WeatherConfigPath: getEnv("WEATHER_CONFIG_PATH", "configs/weather.yaml"),
+
+		AlertsRulesPath:   getEnv("ALERTS_RULES_PATH", "configs/alerts.yaml"),
+		AlertWebhookURL:   getEnv("ALERT_WEBHOOK_URL", ""),
+		AlertTelegramBot:  getEnv("ALERT_TELEGRAM_BOT_TOKEN", ""),
+		AlertTelegramChat: getEnv("ALERT_TELEGRAM_CHAT_ID", ""),
+		AlertSMTPHost:     getEnv("ALERT_SMTP_HOST", ""),
+		AlertSMTPPort:     getEnvInt("ALERT_SMTP_PORT", 587),
+		AlertSMTPUser:     getEnv("ALERT_SMTP_USER", ""),
+		AlertSMTPPassword: getEnv("ALERT_SMTP_PASSWORD", ""),
+		AlertEmailFrom:    getEnv("ALERT_EMAIL_FROM", ""),
+		AlertEmailTo:      getEnv("ALERT_EMAIL_TO", ""),
+
+		HTTPCachePath: getEnv("HTTP_CACHE_PATH", "data/httpcache.db"),
+
+		DataBackend:  getEnv("DATA_BACKEND", "postgres"),
+		InfluxURL:    getEnv("INFLUXDB_URL", "http://localhost:8086"),
+		InfluxToken:  getEnv("INFLUXDB_TOKEN", ""),
+		InfluxOrg:    getEnv("INFLUXDB_ORG", "smartcity"),
+		InfluxBucket: getEnv("INFLUXDB_BUCKET", "telemetry"),
+
+		MQTTBrokerURL: getEnv("MQTT_BROKER_URL", ""),
+		MQTTClientID:  getEnv("MQTT_CLIENT_ID", "smartcity-backend"),
+		MQTTUsername:  getEnv("MQTT_USERNAME", ""),
+		MQTTPassword:  getEnv("MQTT_PASSWORD", ""),
+
+		LocalPredictorWeightsPath:    getEnv("LOCAL_PREDICTOR_WEIGHTS_PATH", "data/local_predictor_weights.json"),
+		LocalPredictorRetrainMinutes: getEnvInt("LOCAL_PREDICTOR_RETRAIN_MINUTES", 60),
+
+		PredictionCacheCapacity:     getEnvInt("PREDICTION_CACHE_CAPACITY", 256),
+		PredictionCacheTTLMinutes:   getEnvInt("PREDICTION_CACHE_TTL_MINUTES", 30),
+		PredictionCacheSnapshotPath: getEnv("PREDICTION_CACHE_SNAPSHOT_PATH", "data/prediction_cache.gob"),
+
+		TransitVehiclePositionsURL: getEnv("TRANSIT_VEHICLE_POSITIONS_URL", ""),
+		TransitTripUpdatesURL:      getEnv("TRANSIT_TRIP_UPDATES_URL", ""),
+		TransitServiceAlertsURL:    getEnv("TRANSIT_SERVICE_ALERTS_URL", ""),
+
+		TrafficHistoryIntervalMinutes: getEnvInt("TRAFFIC_HISTORY_INTERVAL_MINUTES", 15),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate catches the configuration mistakes that would otherwise surface
+// as a confusing failure deep in DI wiring or at first request — e.g. a
+// negative cache TTL silently making every prediction a cache miss.
+func (c *Config) Validate() error {
+	if c.Port == "" {
+		return fmt.Errorf("config: PORT must not be empty")
+	}
+	switch c.DataBackend {
+	case "postgres", "influxdb", "hybrid":
+	default:
+		return fmt.Errorf("config: DATA_BACKEND must be one of postgres, influxdb, hybrid (got %q)", c.DataBackend)
+	}
+	if c.PredictionCacheCapacity < 0 {
+		return fmt.Errorf("config: PREDICTION_CACHE_CAPACITY must not be negative")
+	}
+	if c.PredictionCacheTTLMinutes < 0 {
+		return fmt.Errorf("config: PREDICTION_CACHE_TTL_MINUTES must not be negative")
+	}
+	if c.LocalPredictorRetrainMinutes <= 0 {
+		return fmt.Errorf("config: LOCAL_PREDICTOR_RETRAIN_MINUTES must be positive")
+	}
+	if c.TrafficHistoryIntervalMinutes <= 0 {
+		return fmt.Errorf("config: TRAFFIC_HISTORY_INTERVAL_MINUTES must be positive")
+	}
+	if c.AlertSMTPPort < 0 || c.AlertSMTPPort > 65535 {
+		return fmt.Errorf("config: ALERT_SMTP_PORT must be a valid port number")
+	}
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
@@ -0,0 +1,91 @@
+// Package database constructs the backend's PostgreSQL connection pool.
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	applog "github.com/smartcity/backend/internal/log"
+)
+
+// Config configures pool construction and its startup retry behaviour.
+type Config struct {
+	DatabaseURL string
+
+	// MaxAttempts bounds how many connection attempts (each followed by a
+	// health check) NewPool makes in total before giving up. Default 5.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles after
+	// each subsequent failed attempt. Default 1s.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the doubling above. Default 15s.
+	MaxBackoff time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 15 * time.Second
+	}
+	return c
+}
+
+// NewPool connects to cfg.DatabaseURL and pings it, retrying with
+// exponential backoff up to cfg.MaxAttempts times before giving up. This
+// replaces the previous warn-and-run-with-mock-data-only behaviour for a
+// database that's merely slow to accept connections (e.g. still starting up
+// alongside the backend in docker-compose) rather than truly unavailable —
+// callers that want the old fallback-to-mock behaviour should treat a
+// non-nil error from NewPool as "run in mock mode", exactly as before.
+func NewPool(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
+	cfg = cfg.withDefaults()
+
+	backoff := cfg.InitialBackoff
+	var lastErr error
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		pool, err := connectAndPing(ctx, cfg.DatabaseURL)
+		if err == nil {
+			return pool, nil
+		}
+		lastErr = err
+		applog.Default().Warnf(ctx, "database: connection attempt %d/%d failed: %v", attempt, cfg.MaxAttempts, err)
+
+		if attempt == cfg.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+
+	return nil, fmt.Errorf("database: could not connect after %d attempts: %w", cfg.MaxAttempts, lastErr)
+}
+
+func connectAndPing(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(connectCtx, databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(connectCtx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
@@ -13,26 +13,40 @@ import (
 
 // Handler contains all HTTP handlers
 type Handler struct {
-	dashboardSvc *service.DashboardService
-	mlBridge     *service.MLBridge
-	repo         service.DataRepository
+	dashboardSvc      *service.DashboardService
+	mlBridge          *service.MLBridge
+	predictionCache   *service.PredictionCache
+	repo              service.DataRepository
+	broker            *service.Broker
+	trafficHistorySvc *service.TrafficHistoryService
 }
 
-// NewHandler creates a new handler
-func NewHandler(dashboardSvc *service.DashboardService, mlBridge *service.MLBridge, repo service.DataRepository) *Handler {
+// NewHandler creates a new handler. predictionCache serves Predict (it wraps
+// the fallback predictor chain, see service.CompositePredictor, with an LRU
+// of recent results); mlBridge is used directly only for the
+// Python-service-specific GetStats passthrough. trafficHistorySvc may be nil,
+// in which case the /history/traffic/buckets and /history/traffic/typical
+// endpoints respond
+// 503 instead of panicking.
+func NewHandler(dashboardSvc *service.DashboardService, mlBridge *service.MLBridge, predictionCache *service.PredictionCache, repo service.DataRepository, broker *service.Broker, trafficHistorySvc *service.TrafficHistoryService) *Handler {
 	return &Handler{
-		dashboardSvc: dashboardSvc,
-		mlBridge:     mlBridge,
-		repo:         repo,
+		dashboardSvc:      dashboardSvc,
+		mlBridge:          mlBridge,
+		predictionCache:   predictionCache,
+		repo:              repo,
+		broker:            broker,
+		trafficHistorySvc: trafficHistorySvc,
 	}
 }
 
-// HealthCheck returns service health status
+// HealthCheck returns service health status, including per-provider weather
+// health so operators can see which upstream source is currently serving data.
 func (h *Handler) HealthCheck(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
-		"status":  "ok",
-		"service": "smartcity-backend",
-		"version": "1.0.0",
+		"status":            "ok",
+		"service":           "smartcity-backend",
+		"version":           "1.0.0",
+		"weather_providers": h.dashboardSvc.WeatherProviderHealth(),
 	})
 }
 
@@ -44,6 +58,7 @@ func (h *Handler) GetDashboard(c *fiber.Ctx) error {
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch dashboard data")
 	}
+	data.Traffic.Incidents = resolveIncidentDescriptions(data.Traffic.Incidents, preferredLanguages(c))
 
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -51,11 +66,20 @@ func (h *Handler) GetDashboard(c *fiber.Ctx) error {
 	})
 }
 
-// GetWeather returns current weather data
+// GetWeather returns current weather data. An optional `?provider=` query
+// param (e.g. "met-norway") bypasses the configured fallback chain and
+// fetches directly from that one provider, returning an error if it isn't
+// configured.
 func (h *Handler) GetWeather(c *fiber.Ctx) error {
 	ctx := c.Context()
 
-	weather, err := h.dashboardSvc.GetWeather(ctx)
+	var weather domain.Weather
+	var err error
+	if providerName := c.Query("provider"); providerName != "" {
+		weather, err = h.dashboardSvc.GetWeatherFromProvider(ctx, providerName)
+	} else {
+		weather, err = h.dashboardSvc.GetWeather(ctx)
+	}
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch weather data")
 	}
@@ -74,6 +98,7 @@ func (h *Handler) GetTraffic(c *fiber.Ctx) error {
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch traffic data")
 	}
+	traffic.Incidents = resolveIncidentDescriptions(traffic.Incidents, preferredLanguages(c))
 
 	return c.JSON(domain.TrafficResponse{
 		Data:    traffic,
@@ -81,6 +106,93 @@ func (h *Handler) GetTraffic(c *fiber.Ctx) error {
 	})
 }
 
+// GetForecast returns hourly/daily weather forecasts. Query params `hours`
+// (default 24, max 48) and `days` (default 7, max 16) bound the horizon.
+func (h *Handler) GetForecast(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	hours := c.QueryInt("hours", 24)
+	if hours < 1 || hours > 48 {
+		hours = 24
+	}
+	days := c.QueryInt("days", 7)
+	if days < 1 || days > 16 {
+		days = 7
+	}
+
+	forecast, err := h.dashboardSvc.GetForecast(ctx, hours, days)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch forecast data")
+	}
+
+	return c.JSON(domain.WeatherForecastResponse{
+		Data:    forecast,
+		Success: true,
+	})
+}
+
+// GetAlerts returns the most recently fired/resolved alerts.
+func (h *Handler) GetAlerts(c *fiber.Ctx) error {
+	alerts := h.dashboardSvc.Alerts()
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    alerts,
+		"count":   len(alerts),
+	})
+}
+
+// GetTransitVehicles returns live Almatybus/trolleybus vehicle positions.
+func (h *Handler) GetTransitVehicles(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	vehicles, err := h.dashboardSvc.GetTransitVehicles(ctx)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch transit vehicles")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    vehicles,
+		"count":   len(vehicles),
+	})
+}
+
+// GetTransitAlerts returns active GTFS-Realtime service alerts.
+func (h *Handler) GetTransitAlerts(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	alerts, err := h.dashboardSvc.GetTransitAlerts(ctx)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch transit alerts")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    alerts,
+		"count":   len(alerts),
+	})
+}
+
+// GetTransitTrip returns the predicted stop-level delays for a single trip ID.
+func (h *Handler) GetTransitTrip(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	tripID := c.Params("id")
+	if tripID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Trip ID is required")
+	}
+
+	tripUpdate, err := h.dashboardSvc.GetTransitTripUpdate(ctx, tripID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "No trip update found for this trip ID")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    tripUpdate,
+	})
+}
+
 // Predict proxies prediction requests to Python ML service
 func (h *Handler) Predict(c *fiber.Ctx) error {
 	ctx := c.Context()
@@ -129,7 +241,20 @@ func (h *Handler) Predict(c *fiber.Ctx) error {
 		log.Printf("Could not fetch live data for prediction enrichment: %v", dashErr)
 	}
 
-	prediction, err := h.mlBridge.Predict(ctx, req)
+	// Enrich request with the 3-day forecast trend so predictions can condition
+	// on expected precipitation/temperature change, not just the current reading.
+	if forecast, fErr := h.dashboardSvc.GetForecast(ctx, 24, 3); fErr == nil && len(forecast.Daily) > 0 {
+		precipProb := forecast.Daily[0].PrecipProbability
+		req.ForecastPrecipProbability = &precipProb
+		if len(forecast.Daily) > 1 {
+			trend := forecast.Daily[len(forecast.Daily)-1].TempMax - forecast.Daily[0].TempMax
+			req.ForecastTempTrend = &trend
+		}
+	} else if fErr != nil {
+		log.Printf("Could not fetch forecast for prediction enrichment: %v", fErr)
+	}
+
+	prediction, err := h.predictionCache.Predict(ctx, req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get prediction")
 	}
@@ -160,6 +285,14 @@ func (h *Handler) GetStats(c *fiber.Ctx) error {
 	return c.JSON(result)
 }
 
+// InvalidatePredictionCache clears every cached prediction, forcing the next
+// request for each (date, query, live-data) combination to recompute through
+// the predictor chain. Intended for operator use after an ML model redeploy.
+func (h *Handler) InvalidatePredictionCache(c *fiber.Ctx) error {
+	h.predictionCache.Invalidate()
+	return c.JSON(fiber.Map{"success": true})
+}
+
 // GetHistoricalWeather returns weather history within a time range
 func (h *Handler) GetHistoricalWeather(c *fiber.Ctx) error {
 	ctx := c.Context()
@@ -207,3 +340,95 @@ func (h *Handler) GetHistoricalTraffic(c *fiber.Ctx) error {
 		"count":   len(data),
 	})
 }
+
+// weekdayByAbbrev maps the short day-of-week names accepted by the `dow`
+// query param onto time.Weekday.
+var weekdayByAbbrev = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// GetTrafficHistory returns per-road bucketed congestion/speed averages from
+// the TrafficHistoryService's snapshot history. Query params: `from`/`to`
+// (RFC3339, default the last 24h), `road` (optional, defaults to every
+// road), `bucket` (a Go duration like "15m", default 15m).
+func (h *Handler) GetTrafficHistory(c *fiber.Ctx) error {
+	if h.trafficHistorySvc == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Traffic history is not configured")
+	}
+	ctx := c.Context()
+
+	to := time.Now()
+	if toParam := c.Query("to"); toParam != "" {
+		parsed, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "to must be RFC3339")
+		}
+		to = parsed
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if fromParam := c.Query("from"); fromParam != "" {
+		parsed, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "from must be RFC3339")
+		}
+		from = parsed
+	}
+
+	bucket := 15 * time.Minute
+	if bucketParam := c.Query("bucket"); bucketParam != "" {
+		parsed, err := time.ParseDuration(bucketParam)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "bucket must be a duration like 15m")
+		}
+		bucket = parsed
+	}
+
+	buckets, err := h.trafficHistorySvc.History(ctx, from, to, c.Query("road"), bucket)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch traffic history")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    buckets,
+		"count":   len(buckets),
+	})
+}
+
+// GetTrafficTypical returns the long-run mean+stddev congestion recorded for
+// a given day-of-week/hour-of-day, e.g. "how bad is a typical Monday 8am".
+// Query params: `dow` (Sun..Sat, default Mon), `hour` (0-23, default 8).
+func (h *Handler) GetTrafficTypical(c *fiber.Ctx) error {
+	if h.trafficHistorySvc == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Traffic history is not configured")
+	}
+	ctx := c.Context()
+
+	dowParam := c.Query("dow", "Mon")
+	dow, ok := weekdayByAbbrev[dowParam]
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "dow must be one of Sun, Mon, Tue, Wed, Thu, Fri, Sat")
+	}
+
+	hour := c.QueryInt("hour", 8)
+	if hour < 0 || hour > 23 {
+		return fiber.NewError(fiber.StatusBadRequest, "hour must be between 0 and 23")
+	}
+
+	typical, err := h.trafficHistorySvc.Typical(ctx, dow, hour)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch typical congestion")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    typical,
+	})
+}
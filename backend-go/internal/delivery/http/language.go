@@ -0,0 +1,97 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/language"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// supportedLanguages is Almaty's tri-lingual set. Russian is listed first
+// since it has long been this project's default.
+var supportedLanguages = []language.Tag{
+	language.Russian,
+	language.English,
+	language.Kazakh,
+}
+
+const localsPreferredLanguages = "preferredLanguages"
+
+// LanguageMiddleware parses the Accept-Language header and stashes the
+// client's preferred language tags on c.Locals, for handlers that serve
+// content available in multiple languages (see resolveIncidentDescriptions).
+func LanguageMiddleware(c *fiber.Ctx) error {
+	tags, _, err := language.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+	if err != nil || len(tags) == 0 {
+		tags = []language.Tag{language.Russian}
+	}
+	c.Locals(localsPreferredLanguages, tags)
+	return c.Next()
+}
+
+// preferredLanguages reads back the tags LanguageMiddleware stashed, falling
+// back to Russian-only if the middleware didn't run on this request.
+func preferredLanguages(c *fiber.Ctx) []language.Tag {
+	if tags, ok := c.Locals(localsPreferredLanguages).([]language.Tag); ok {
+		return tags
+	}
+	return []language.Tag{language.Russian}
+}
+
+// selectLanguageByTag picks the best available translation for preferred out
+// of translations, matching against only the languages actually present.
+// available is built from the fixed supportedLanguages order (Russian
+// first), not from ranging over translations directly, since map iteration
+// order is randomized and language.NewMatcher's no-match default is simply
+// the first tag in the slice it's given — ranging over the map would make
+// the "falls back to Russian" behavior below non-deterministic. Falls back
+// to Russian, then to any available translation, if nothing matches.
+func selectLanguageByTag(translations map[language.Tag]string, preferred []language.Tag) (language.Tag, string) {
+	if len(translations) == 0 {
+		return language.Und, ""
+	}
+
+	available := make([]language.Tag, 0, len(supportedLanguages))
+	for _, tag := range supportedLanguages {
+		if _, ok := translations[tag]; ok {
+			available = append(available, tag)
+		}
+	}
+	if len(available) == 0 {
+		// translations has keys outside supportedLanguages only; return any
+		// one of them.
+		for t, text := range translations {
+			return t, text
+		}
+	}
+
+	matcher := language.NewMatcher(available)
+	// Match may return a tag carrying extra subtags (e.g. a region extension),
+	// which is not a key of translations; its index points at the matched
+	// entry of available instead.
+	_, idx, _ := matcher.Match(preferred...)
+	tag := available[idx]
+	if text, ok := translations[tag]; ok {
+		return tag, text
+	}
+	if text, ok := translations[language.Russian]; ok {
+		return language.Russian, text
+	}
+	for t, text := range translations {
+		return t, text
+	}
+	return language.Und, ""
+}
+
+// resolveIncidentDescriptions returns a copy of incidents with Description
+// resolved to the best-matching translation for preferred, so clients get
+// localized text without needing to understand the Descriptions map.
+func resolveIncidentDescriptions(incidents []domain.Incident, preferred []language.Tag) []domain.Incident {
+	resolved := make([]domain.Incident, len(incidents))
+	for i, inc := range incidents {
+		_, text := selectLanguageByTag(inc.Descriptions, preferred)
+		inc.Description = text
+		resolved[i] = inc
+	}
+	return resolved
+}
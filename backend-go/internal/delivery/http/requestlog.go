@@ -0,0 +1,32 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	applog "github.com/smartcity/backend/internal/log"
+)
+
+const localsRequestID = "requestID"
+
+// RequestLogMiddleware assigns each request a request ID (reusing an
+// incoming X-Request-ID header if present, so it survives a reverse proxy),
+// stashes it on c.Locals and on the request's context (so downstream service
+// calls log with it via internal/log), and logs the request's method, path,
+// status and latency once it completes.
+func RequestLogMiddleware(c *fiber.Ctx) error {
+	id := c.Get("X-Request-ID")
+	if id == "" {
+		id = applog.NewRequestID()
+	}
+	c.Locals(localsRequestID, id)
+	c.SetUserContext(applog.WithRequestID(c.UserContext(), id))
+	c.Set("X-Request-ID", id)
+
+	start := time.Now()
+	err := c.Next()
+	applog.Default().Infof(c.UserContext(), "%s %s -> %d (%s)", c.Method(), c.Path(), c.Response().StatusCode(), time.Since(start))
+
+	return err
+}
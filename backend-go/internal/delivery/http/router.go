@@ -2,16 +2,22 @@ package http
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/websocket/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/smartcity/backend/internal/service"
 )
 
 // SetupRoutes configures all HTTP routes
-func SetupRoutes(app *fiber.App, dashboardSvc *service.DashboardService, mlBridge *service.MLBridge, repo service.DataRepository) {
-	handler := NewHandler(dashboardSvc, mlBridge, repo)
+func SetupRoutes(app *fiber.App, dashboardSvc *service.DashboardService, mlBridge *service.MLBridge, predictionCache *service.PredictionCache, repo service.DataRepository, broker *service.Broker, trafficHistorySvc *service.TrafficHistoryService) {
+	handler := NewHandler(dashboardSvc, mlBridge, predictionCache, repo, broker, trafficHistorySvc)
 
 	// Health check
 	app.Get("/health", handler.HealthCheck)
 
+	// Prometheus/OpenMetrics exporter
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
 	// API v1 routes
 	api := app.Group("/api/v1")
 	{
@@ -19,12 +25,37 @@ func SetupRoutes(app *fiber.App, dashboardSvc *service.DashboardService, mlBridg
 		api.Get("/dashboard", handler.GetDashboard)
 		api.Get("/weather", handler.GetWeather)
 		api.Get("/traffic", handler.GetTraffic)
+		api.Get("/forecast", handler.GetForecast)
+		api.Get("/alerts", handler.GetAlerts)
+
+		// Transit endpoints — live GTFS-Realtime vehicle positions, service
+		// alerts, and per-trip delay predictions
+		api.Get("/transit/vehicles", handler.GetTransitVehicles)
+		api.Get("/transit/alerts", handler.GetTransitAlerts)
+		api.Get("/transit/trip/:id", handler.GetTransitTrip)
+
+		// Live streaming endpoints — push dashboard snapshots from a single
+		// shared Broker instead of every client polling independently.
+		api.Get("/stream", handler.StreamDashboardSSE)
+		api.Get("/ws", websocket.New(handler.StreamDashboardWS))
 
 		// History endpoints
 		api.Get("/history/weather", handler.GetHistoricalWeather)
 		api.Get("/history/traffic", handler.GetHistoricalTraffic)
 
-		// Prediction endpoint (proxies to Python ML service)
+		// Per-road traffic history, backed by TrafficHistoryService's periodic
+		// snapshots rather than DataRepository's single aggregate-row history
+		// above. With no database configured, both routes serve mock history.
+		api.Get("/history/traffic/buckets", handler.GetTrafficHistory)
+		api.Get("/history/traffic/typical", handler.GetTrafficTypical)
+
+		// Prediction endpoint (falls back through the configured predictor
+		// chain — ML service, then local regression, then mock — on outage)
 		api.Post("/predict", handler.Predict)
+		api.Get("/stats", handler.GetStats)
+
+		// Admin endpoints
+		admin := api.Group("/admin")
+		admin.Post("/predictions/cache/invalidate", handler.InvalidatePredictionCache)
 	}
 }
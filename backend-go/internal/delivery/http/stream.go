@@ -0,0 +1,146 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+
+	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/service"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+// parseFields splits a comma-separated `?fields=weather,traffic` query value
+// into its trimmed, non-empty parts. An empty result means "no filter",
+// i.e. send every field.
+func parseFields(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	fields := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			fields = append(fields, p)
+		}
+	}
+	return fields
+}
+
+// filterDashboardData narrows a DashboardSnapshot's payload to the requested
+// top-level fields ("weather", "traffic"). timestamp is always included so
+// clients can tell how fresh a partial snapshot is. An empty fields list
+// returns data unfiltered.
+func filterDashboardData(data domain.DashboardData, fields []string) interface{} {
+	if len(fields) == 0 {
+		return data
+	}
+	out := fiber.Map{"timestamp": data.Timestamp}
+	for _, f := range fields {
+		switch f {
+		case "weather":
+			out["weather"] = data.Weather
+		case "traffic":
+			out["traffic"] = data.Traffic
+		}
+	}
+	return out
+}
+
+// StreamDashboardSSE streams DashboardSnapshots as Server-Sent Events.
+// Clients may set Last-Event-ID (header or ?last_event_id=) to replay the
+// last few snapshots buffered by the broker after a reconnect, and
+// ?fields=weather,traffic to receive only those top-level fields.
+func (h *Handler) StreamDashboardSSE(c *fiber.Ctx) error {
+	lastEventID := parseLastEventID(c)
+	fields := parseFields(c.Query("fields"))
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	id, ch, replay := h.broker.Subscribe(lastEventID)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.broker.Unsubscribe(id)
+
+		writeSnapshot := func(snap service.DashboardSnapshot) bool {
+			payload, err := json.Marshal(filterDashboardData(snap.Data, fields))
+			if err != nil {
+				return true // skip malformed snapshot, keep the connection open
+			}
+			fmt.Fprintf(w, "id: %d\n", snap.ID)
+			fmt.Fprintf(w, "event: dashboard\n")
+			fmt.Fprintf(w, "retry: 3000\n")
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			return w.Flush() == nil
+		}
+
+		for _, snap := range replay {
+			if !writeSnapshot(snap) {
+				return
+			}
+		}
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case snap, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !writeSnapshot(snap) {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || w.Flush() != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+func parseLastEventID(c *fiber.Ctx) int64 {
+	raw := c.Get("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	id, _ := strconv.ParseInt(raw, 10, 64)
+	return id
+}
+
+// StreamDashboardWS upgrades to a WebSocket connection and pushes every new
+// DashboardSnapshot as a JSON text frame — the fallback for clients that
+// can't use SSE. Mount behind websocket.New so the *websocket.Conn is valid.
+// Supports the same ?fields=weather,traffic filter as StreamDashboardSSE.
+func (h *Handler) StreamDashboardWS(c *websocket.Conn) {
+	fields := parseFields(c.Query("fields"))
+
+	id, ch, replay := h.broker.Subscribe(0)
+	defer h.broker.Unsubscribe(id)
+
+	for _, snap := range replay {
+		if c.WriteJSON(filterDashboardData(snap.Data, fields)) != nil {
+			return
+		}
+	}
+
+	for snap := range ch {
+		if c.WriteJSON(filterDashboardData(snap.Data, fields)) != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ForecastHour represents a single hourly forecast entry.
+type ForecastHour struct {
+	Time              time.Time `json:"time"`
+	Temperature       float64   `json:"temperature"`
+	PrecipProbability int       `json:"precip_probability"` // 0-100
+	PrecipType        string    `json:"precip_type,omitempty"`
+	WindSpeed         float64   `json:"wind_speed"` // m/s
+	WeatherCode       int       `json:"weather_code"`
+	Description       string    `json:"description"`
+	Icon              string    `json:"icon"`
+}
+
+// ForecastDay represents a single daily forecast entry.
+type ForecastDay struct {
+	Date              string  `json:"date"` // YYYY-MM-DD
+	TempMin           float64 `json:"temp_min"`
+	TempMax           float64 `json:"temp_max"`
+	PrecipProbability int     `json:"precip_probability"` // 0-100
+	PrecipType        string  `json:"precip_type,omitempty"`
+	WindSpeed         float64 `json:"wind_speed"` // m/s
+	WeatherCode       int     `json:"weather_code"`
+	Description       string  `json:"description"`
+	Icon              string  `json:"icon"`
+	Sunrise           string  `json:"sunrise,omitempty"`
+	Sunset            string  `json:"sunset,omitempty"`
+	MoonPhase         float64 `json:"moon_phase,omitempty"` // 0.0 new, 0.5 full, nearing 1.0 as it returns to new
+}
+
+// WeatherForecast bundles hourly and daily forecast horizons for a location.
+type WeatherForecast struct {
+	Hourly    []ForecastHour `json:"hourly"`
+	Daily     []ForecastDay  `json:"daily"`
+	Timestamp time.Time      `json:"timestamp"`
+	IsMock    bool           `json:"is_mock"`
+}
+
+// WeatherForecastResponse wraps forecast data with metadata.
+type WeatherForecastResponse struct {
+	Data    WeatherForecast `json:"data"`
+	Success bool            `json:"success"`
+	Message string          `json:"message,omitempty"`
+}
@@ -9,6 +9,7 @@ import (
 type DashboardData struct {
 	Weather   Weather   `json:"weather"`
 	Traffic   Traffic   `json:"traffic"`
+	Vehicles  []Vehicle `json:"vehicles,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -22,6 +23,11 @@ type PredictionRequest struct {
 	LiveAQI     *int     `json:"live_aqi,omitempty"`
 	LiveTraffic *float64 `json:"live_traffic,omitempty"`
 	LiveTemp    *float64 `json:"live_temp,omitempty"`
+	// Forecast-derived fields — enriched from WeatherService.GetForecast so
+	// predictions can condition on expected precipitation/temperature trend
+	// instead of just the request date's month.
+	ForecastPrecipProbability *int     `json:"forecast_precip_probability,omitempty"`
+	ForecastTempTrend         *float64 `json:"forecast_temp_trend,omitempty"` // °C change, today→+2d (first to last day of a 3-day forecast)
 }
 
 // PredictionResponse represents AI prediction output
@@ -32,6 +38,56 @@ type PredictionResponse struct {
 	TrafficIndex    float64 `json:"traffic_index_prediction"`
 	Reasoning       string  `json:"reasoning"`
 	IsMock          bool    `json:"is_mock"`
+	// Degraded marks a response served by a fallback Predictor (e.g.
+	// LocalPredictor) rather than the primary Python ML service.
+	Degraded bool `json:"degraded"`
+}
+
+// TrafficHistoryBucket is a time-bucketed average over one or more
+// TrafficHistoryRepository.SaveSnapshot calls for a single road.
+type TrafficHistoryBucket struct {
+	BucketStart        time.Time `json:"bucket_start"`
+	RoadName           string    `json:"road_name"`
+	AvgCongestion      float64   `json:"avg_congestion"`
+	AvgSpeed           float64   `json:"avg_speed"`
+	AvgCongestionIndex float64   `json:"avg_congestion_index"`
+	AvgIncidentCount   float64   `json:"avg_incident_count"`
+	SampleCount        int       `json:"sample_count"`
+}
+
+// TrafficTypical is the long-run mean+stddev congestion recorded for a
+// specific day-of-week and hour-of-day, e.g. "how congested is a typical
+// Monday 8am" — the data-driven baseline calculateCongestionIndex's
+// hardcoded rush-hour heuristics can migrate to once enough history
+// accumulates. MeanCongestionIndex/MeanIncidentCount are the overall
+// snapshot-level averages (not per-segment), for exactly that baseline.
+type TrafficTypical struct {
+	DayOfWeek           string  `json:"day_of_week"`
+	Hour                int     `json:"hour"`
+	MeanCongestion      float64 `json:"mean_congestion"`
+	StddevCongestion    float64 `json:"stddev_congestion"`
+	MeanCongestionIndex float64 `json:"mean_congestion_index"`
+	MeanIncidentCount   float64 `json:"mean_incident_count"`
+	SampleCount         int     `json:"sample_count"`
+}
+
+// TrafficHistoryRepository persists one row per RoadSegment per Traffic
+// snapshot (keyed by timestamp + road name) and answers historical queries
+// over them. This is distinct from DataRepository.SaveTrafficData, which
+// stores a single aggregate-congestion row per poll with no per-road detail.
+type TrafficHistoryRepository interface {
+	// SaveSnapshot persists every RoadSegment in snapshot as one row each,
+	// sharing snapshot.Timestamp.
+	SaveSnapshot(ctx context.Context, snapshot Traffic) error
+
+	// History returns bucketed averages between from and to, bucketed by
+	// bucket (e.g. 15*time.Minute), optionally filtered to a single road
+	// name ("" means every road).
+	History(ctx context.Context, from, to time.Time, road string, bucket time.Duration) ([]TrafficHistoryBucket, error)
+
+	// Typical returns the long-run mean+stddev congestion recorded for the
+	// given day-of-week and hour-of-day, across all history.
+	Typical(ctx context.Context, dayOfWeek time.Weekday, hour int) (TrafficTypical, error)
 }
 
 // DataRepository defines the interface for data persistence
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
 
 // HeatmapPoint represents a single point for Deck.gl visualization
 type HeatmapPoint struct {
@@ -20,10 +24,19 @@ type RoadSegment struct {
 
 // Incident represents a road event like an accident or roadwork
 type Incident struct {
-	Latitude    float64 `json:"lat"`
-	Longitude   float64 `json:"lon"`
-	Type        string  `json:"type"` // "accident", "roadwork", "police"
-	Description string  `json:"description"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+	Type      string  `json:"type"` // "accident", "roadwork", "police"
+	// Description is Descriptions resolved to the request's preferred
+	// language (see the http package's resolveIncidentDescriptions);
+	// unresolved Traffic values carry only Descriptions.
+	Description string `json:"description"`
+	// Descriptions holds the same incident description fetched in every
+	// supported language, keyed by BCP-47 tag.
+	Descriptions map[language.Tag]string `json:"descriptions,omitempty"`
+	// RoadName is the nearest RoadSegment.Name this incident was snapped to,
+	// within snapMaxDistanceMeters. Empty if no road segment was close enough.
+	RoadName string `json:"road_name,omitempty"`
 }
 
 // Traffic represents traffic data with congestion metrics
@@ -38,6 +51,9 @@ type Traffic struct {
 	IncidentCount   int            `json:"incident_count"`
 	Timestamp       time.Time      `json:"timestamp"`
 	IsMock          bool           `json:"is_mock"`
+	// Source names where this reading came from, e.g. "tomtom" or an MQTT
+	// sensor ID like "mqtt:sensor-12". Empty for mock data.
+	Source string `json:"source,omitempty"`
 }
 
 // TrafficResponse wraps traffic data with metadata
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// Vehicle represents a single live transit vehicle (bus/trolleybus) position,
+// decoded from a GTFS-Realtime VehiclePosition entity.
+type Vehicle struct {
+	ID              string    `json:"id"`
+	TripID          string    `json:"trip_id,omitempty"`
+	RouteID         string    `json:"route_id,omitempty"`
+	Latitude        float64   `json:"lat"`
+	Longitude       float64   `json:"lon"`
+	Bearing         float64   `json:"bearing,omitempty"`
+	SpeedKmh        float64   `json:"speed_kmh,omitempty"`
+	OccupancyStatus string    `json:"occupancy_status,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// StopTimeUpdate is one stop's predicted arrival/departure delay within a
+// TripUpdate.
+type StopTimeUpdate struct {
+	StopID         string `json:"stop_id,omitempty"`
+	StopSequence   int    `json:"stop_sequence,omitempty"`
+	ArrivalDelay   int    `json:"arrival_delay_seconds,omitempty"`
+	DepartureDelay int    `json:"departure_delay_seconds,omitempty"`
+}
+
+// TripUpdate carries the predicted stop-level delays for a single trip,
+// decoded from a GTFS-Realtime TripUpdate entity.
+type TripUpdate struct {
+	TripID          string           `json:"trip_id"`
+	RouteID         string           `json:"route_id,omitempty"`
+	StopTimeUpdates []StopTimeUpdate `json:"stop_time_updates"`
+}
+
+// TransitAlert is a GTFS-Realtime service alert (detour, delay,
+// cancellation) affecting one or more routes/stops/trips.
+type TransitAlert struct {
+	ID          string    `json:"id"`
+	Cause       string    `json:"cause,omitempty"`
+	Effect      string    `json:"effect,omitempty"`
+	Header      string    `json:"header"`
+	Description string    `json:"description,omitempty"`
+	RouteIDs    []string  `json:"route_ids,omitempty"`
+	StopIDs     []string  `json:"stop_ids,omitempty"`
+	ActiveFrom  time.Time `json:"active_from,omitempty"`
+	ActiveUntil time.Time `json:"active_until,omitempty"`
+}
+
+// TransitSnapshot is the cached result of one GTFS-Realtime feed refresh
+// across the vehicle-positions, trip-updates, and service-alerts feeds.
+type TransitSnapshot struct {
+	Vehicles    []Vehicle      `json:"vehicles"`
+	TripUpdates []TripUpdate   `json:"trip_updates"`
+	Alerts      []TransitAlert `json:"alerts"`
+	Timestamp   time.Time      `json:"timestamp"`
+	IsMock      bool           `json:"is_mock"`
+}
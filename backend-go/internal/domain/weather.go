@@ -17,6 +17,20 @@ type Weather struct {
 	Country     string    `json:"country"`
 	Timestamp   time.Time `json:"timestamp"`
 	IsMock      bool      `json:"is_mock"`
+	// Source names the provider that served this reading, e.g. "open-meteo",
+	// "openweathermap", "met-norway", "noaa-metar". Empty for mock data.
+	Source string `json:"source,omitempty"`
+	// DominantPollutant is the pollutant whose sub-index equals the overall
+	// AQI (AQI = max of all PollutantSubIndex entries), per EPA convention.
+	DominantPollutant string              `json:"dominant_pollutant,omitempty"`
+	PollutantSubIndex []PollutantSubIndex `json:"pollutant_sub_index,omitempty"`
+}
+
+// PollutantSubIndex is one pollutant's contribution to the overall AQI.
+type PollutantSubIndex struct {
+	Pollutant     string  `json:"pollutant"` // "pm25", "pm10", "o3_8h", "o3_1h", "no2", "so2", "co"
+	Concentration float64 `json:"concentration"`
+	SubIndex      int     `json:"sub_index"`
 }
 
 // WeatherResponse wraps weather data with metadata
@@ -0,0 +1,96 @@
+// Package geoutils provides small local-planar geometry helpers for
+// snapping points to road polylines. Distances are approximated in an
+// ENU (east-north-up) projection around the line segment being tested,
+// which is accurate to a few centimeters at Almaty's latitude over the
+// few-hundred-meter distances these snaps operate on.
+package geoutils
+
+import (
+	"fmt"
+	"math"
+)
+
+// metersPerDegreeLat is the distance in meters spanned by one degree of
+// latitude; it varies negligibly with latitude itself over Earth's
+// ellipsoid, so a single constant is accurate enough for this purpose.
+const metersPerDegreeLat = 111_320.0
+
+// Point is a geographic coordinate (WGS84 latitude/longitude in degrees).
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// toMeters converts p's lat/lon delta from origin into local east/north
+// meters, scaling longitude by cos(latitude) since a degree of longitude
+// shrinks toward the poles.
+func toMeters(p, origin Point) (east, north float64) {
+	metersPerDegreeLon := metersPerDegreeLat * math.Cos(origin.Lat*math.Pi/180)
+	east = (p.Lon - origin.Lon) * metersPerDegreeLon
+	north = (p.Lat - origin.Lat) * metersPerDegreeLat
+	return east, north
+}
+
+// fromMeters is the inverse of toMeters, reconstructing a Point from local
+// east/north meters relative to origin.
+func fromMeters(east, north float64, origin Point) Point {
+	metersPerDegreeLon := metersPerDegreeLat * math.Cos(origin.Lat*math.Pi/180)
+	return Point{
+		Lat: origin.Lat + north/metersPerDegreeLat,
+		Lon: origin.Lon + east/metersPerDegreeLon,
+	}
+}
+
+// projectToSegment projects p onto the segment a-b by projecting the vector
+// p-a onto b-a in local meters, clamping the scalar projection t to [0,1]
+// so the result always lies within the segment rather than its extension.
+func projectToSegment(p, a, b Point) (proj Point, distanceMeters float64) {
+	pEast, pNorth := toMeters(p, a)
+	bEast, bNorth := toMeters(b, a)
+
+	segLenSq := bEast*bEast + bNorth*bNorth
+	var t float64
+	if segLenSq > 0 {
+		t = (pEast*bEast + pNorth*bNorth) / segLenSq
+	}
+	t = math.Max(0, math.Min(1, t))
+
+	projEast := t * bEast
+	projNorth := t * bNorth
+	proj = fromMeters(projEast, projNorth, a)
+
+	dEast := pEast - projEast
+	dNorth := pNorth - projNorth
+	distanceMeters = math.Hypot(dEast, dNorth)
+
+	return proj, distanceMeters
+}
+
+// ClosestPointOnLineString returns the closest point on lineString to point,
+// its distance in meters, and the index of the segment's starting vertex —
+// callers that only need the distance can ignore the returned point.
+func ClosestPointOnLineString(point Point, lineString []Point) (closest Point, distanceMeters float64, segmentIndex int) {
+	distanceMeters = math.Inf(1)
+	segmentIndex = -1
+
+	for i := 0; i+1 < len(lineString); i++ {
+		proj, d := projectToSegment(point, lineString[i], lineString[i+1])
+		if d < distanceMeters {
+			distanceMeters = d
+			segmentIndex = i
+			closest = proj
+		}
+	}
+
+	return closest, distanceMeters, segmentIndex
+}
+
+// RoundedKey returns a stable string key for p rounded to precision decimal
+// degrees (3 decimals ≈ 111m at Almaty's latitude), for deduplicating
+// near-duplicate points reported by multiple independent data sources.
+func RoundedKey(p Point, precision int) string {
+	scale := math.Pow(10, float64(precision))
+	lat := math.Round(p.Lat*scale) / scale
+	lon := math.Round(p.Lon*scale) / scale
+	return fmt.Sprintf("%.*f,%.*f", precision, lat, precision, lon)
+}
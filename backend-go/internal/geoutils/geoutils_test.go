@@ -0,0 +1,111 @@
+package geoutils
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestClosestPointOnLineString_ExactVertex(t *testing.T) {
+	line := []Point{
+		{Lat: 43.2220, Lon: 76.8512},
+		{Lat: 43.2230, Lon: 76.8522},
+		{Lat: 43.2240, Lon: 76.8532},
+	}
+
+	closest, dist, segmentIndex := ClosestPointOnLineString(line[1], line)
+
+	if !almostEqual(dist, 0, 0.01) {
+		t.Errorf("distance from a point on the line to itself = %v, want ~0", dist)
+	}
+	if segmentIndex != 0 {
+		t.Errorf("segmentIndex = %d, want 0 (vertex sits at the end of the first segment)", segmentIndex)
+	}
+	if !almostEqual(closest.Lat, line[1].Lat, 1e-6) || !almostEqual(closest.Lon, line[1].Lon, 1e-6) {
+		t.Errorf("closest = %+v, want %+v", closest, line[1])
+	}
+}
+
+func TestClosestPointOnLineString_PerpendicularOffset(t *testing.T) {
+	// A short east-west segment; offsetting north by ~0.001 deg should project
+	// back onto the segment at roughly the same longitude, a known distance away.
+	line := []Point{
+		{Lat: 43.2220, Lon: 76.8500},
+		{Lat: 43.2220, Lon: 76.8600},
+	}
+	off := Point{Lat: 43.2230, Lon: 76.8550}
+
+	closest, dist, segmentIndex := ClosestPointOnLineString(off, line)
+
+	if segmentIndex != 0 {
+		t.Errorf("segmentIndex = %d, want 0", segmentIndex)
+	}
+	if !almostEqual(closest.Lon, off.Lon, 1e-6) {
+		t.Errorf("closest.Lon = %v, want ~%v (perpendicular projection keeps longitude)", closest.Lon, off.Lon)
+	}
+	wantDist := (off.Lat - line[0].Lat) * metersPerDegreeLat
+	if !almostEqual(dist, wantDist, 1.0) {
+		t.Errorf("dist = %v, want ~%v", dist, wantDist)
+	}
+}
+
+func TestClosestPointOnLineString_ClampsPastSegmentEnds(t *testing.T) {
+	line := []Point{
+		{Lat: 43.2220, Lon: 76.8500},
+		{Lat: 43.2220, Lon: 76.8600},
+	}
+	// Due west of the line's start, off the end of the segment.
+	beforeStart := Point{Lat: 43.2220, Lon: 76.8400}
+
+	closest, _, segmentIndex := ClosestPointOnLineString(beforeStart, line)
+
+	if segmentIndex != 0 {
+		t.Errorf("segmentIndex = %d, want 0", segmentIndex)
+	}
+	if !almostEqual(closest.Lat, line[0].Lat, 1e-6) || !almostEqual(closest.Lon, line[0].Lon, 1e-6) {
+		t.Errorf("closest = %+v, want the clamped segment start %+v", closest, line[0])
+	}
+}
+
+func TestClosestPointOnLineString_PicksNearestOfMultipleSegments(t *testing.T) {
+	line := []Point{
+		{Lat: 43.2200, Lon: 76.8500},
+		{Lat: 43.2210, Lon: 76.8500},
+		{Lat: 43.2220, Lon: 76.8600},
+	}
+	near := Point{Lat: 43.2219, Lon: 76.8601}
+
+	_, _, segmentIndex := ClosestPointOnLineString(near, line)
+
+	if segmentIndex != 1 {
+		t.Errorf("segmentIndex = %d, want 1 (second segment is closest)", segmentIndex)
+	}
+}
+
+func TestClosestPointOnLineString_EmptyOrSinglePoint(t *testing.T) {
+	_, dist, idx := ClosestPointOnLineString(Point{Lat: 1, Lon: 1}, nil)
+	if !math.IsInf(dist, 1) || idx != -1 {
+		t.Errorf("empty lineString: got dist=%v idx=%d, want +Inf, -1", dist, idx)
+	}
+
+	_, dist, idx = ClosestPointOnLineString(Point{Lat: 1, Lon: 1}, []Point{{Lat: 0, Lon: 0}})
+	if !math.IsInf(dist, 1) || idx != -1 {
+		t.Errorf("single-point lineString: got dist=%v idx=%d, want +Inf, -1", dist, idx)
+	}
+}
+
+func TestRoundedKey(t *testing.T) {
+	a := RoundedKey(Point{Lat: 43.22201, Lon: 76.85119}, 3)
+	b := RoundedKey(Point{Lat: 43.22204, Lon: 76.85122}, 3)
+	if a != b {
+		t.Errorf("RoundedKey should dedupe near-duplicate points at 3 decimals: %q != %q", a, b)
+	}
+
+	c := RoundedKey(Point{Lat: 43.2230, Lon: 76.8512}, 3)
+	if a == c {
+		t.Errorf("RoundedKey should distinguish points 0.001 deg apart: got equal keys %q", a)
+	}
+}
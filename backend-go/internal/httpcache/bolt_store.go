@@ -0,0 +1,74 @@
+package httpcache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltBucket holds every cached Entry, gob-encoded, keyed by request URL.
+var boltBucket = []byte("httpcache")
+
+// BoltStore persists cache Entries to a local BoltDB file, so the
+// last-known-good response for each upstream survives a process restart
+// (cutting cold-start latency after a deploy).
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: failed to open bolt store %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("httpcache: failed to init bolt store %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) Get(key string) (Entry, bool) {
+	var entry Entry
+	var found bool
+
+	_ = s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(boltBucket).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&entry); err != nil {
+			return nil
+		}
+		found = true
+		return nil
+	})
+
+	return entry, found
+}
+
+func (s *BoltStore) Set(key string, entry Entry) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+		return fmt.Errorf("httpcache: failed to encode entry for %s: %w", key, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put([]byte(key), buf.Bytes())
+	})
+}
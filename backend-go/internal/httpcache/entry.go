@@ -0,0 +1,114 @@
+// Package httpcache implements an HTTP cache transport that honors
+// Cache-Control/Expires freshness and ETag/Last-Modified conditional
+// revalidation, per RFC 7234. It is used to avoid re-fetching unchanged
+// upstream responses (e.g. MET Norway, whose ToS requires conditional
+// requests) and to survive process restarts when backed by a persistent Store.
+package httpcache
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is one cached response, persisted verbatim (status line, headers,
+// body) so it can be replayed as an *http.Response without re-parsing.
+type Entry struct {
+	URL         string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	RequestVary http.Header // values of the Vary-listed request headers at fetch time
+	FetchedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// newEntry builds an Entry from a response whose body has already been
+// drained into body, and the request that produced it.
+func newEntry(req *http.Request, resp *http.Response, body []byte, now time.Time) Entry {
+	e := Entry{
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		FetchedAt:  now,
+		ExpiresAt:  computeExpiry(resp.Header, now),
+	}
+	if vary := resp.Header.Get("Vary"); vary != "" && vary != "*" {
+		e.RequestVary = make(http.Header)
+		for _, name := range strings.Split(vary, ",") {
+			name = strings.TrimSpace(name)
+			if v := req.Header.Get(name); v != "" {
+				e.RequestVary.Set(name, v)
+			}
+		}
+	}
+	return e
+}
+
+// fresh reports whether the entry can be served without revalidation.
+func (e *Entry) fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// matchesVary reports whether req's Vary-listed header values match those
+// recorded when the entry was fetched (RFC 7234 §4.1).
+func (e *Entry) matchesVary(req *http.Request) bool {
+	for name, want := range e.RequestVary {
+		if req.Header.Get(name) != strings.Join(want, ", ") {
+			return false
+		}
+	}
+	return true
+}
+
+// toResponse replays the cached entry as an *http.Response for req.
+func (e *Entry) toResponse(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// computeExpiry derives a freshness deadline from Cache-Control/Expires. When
+// neither is present, a short heuristic TTL is used so uncacheable-looking
+// responses (missing explicit directives) still get some benefit without
+// risking serving clearly stale data.
+func computeExpiry(header http.Header, now time.Time) time.Time {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(strings.ToLower(directive))
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return now
+		case strings.HasPrefix(directive, "max-age="):
+			if secs, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				return now.Add(time.Duration(secs) * time.Second)
+			}
+		}
+	}
+	if expires := header.Get("Expires"); expires != "" {
+		if t, err := http.ParseTime(expires); err == nil {
+			return t
+		}
+	}
+	return now.Add(5 * time.Minute)
+}
+
+// isCacheable reports whether resp may be stored at all.
+func isCacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+}
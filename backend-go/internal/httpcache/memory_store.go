@@ -0,0 +1,68 @@
+package httpcache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// MemoryStore is an in-process LRU Store for CachingTransport. It does not
+// survive a restart — pair it with a
+// persistent Store (e.g. BoltStore) when that matters.
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // front = most recently used
+}
+
+type memoryStoreEntry struct {
+	key   string
+	entry Entry
+}
+
+// NewMemoryStore creates a MemoryStore holding up to capacity entries.
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = 256
+	}
+	return &MemoryStore{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (s *MemoryStore) Get(key string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return Entry{}, false
+	}
+	s.order.MoveToFront(el)
+	return el.Value.(*memoryStoreEntry).entry, true
+}
+
+func (s *MemoryStore) Set(key string, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		el.Value.(*memoryStoreEntry).entry = entry
+		s.order.MoveToFront(el)
+		return nil
+	}
+
+	el := s.order.PushFront(&memoryStoreEntry{key: key, entry: entry})
+	s.items[key] = el
+
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		if oldest != nil {
+			s.order.Remove(oldest)
+			delete(s.items, oldest.Value.(*memoryStoreEntry).key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package httpcache
+
+// Store persists cache Entries, keyed by request URL. Implementations need
+// not enforce Vary matching themselves — CachingTransport checks
+// Entry.matchesVary after Get and treats a mismatch as a miss.
+type Store interface {
+	Get(key string) (Entry, bool)
+	Set(key string, entry Entry) error
+}
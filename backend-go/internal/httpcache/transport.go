@@ -0,0 +1,89 @@
+package httpcache
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// CachingTransport is an http.RoundTripper that serves GET responses from
+// Store when fresh, revalidates via If-None-Match/If-Modified-Since when
+// stale, and falls back to serving stale data if the upstream is unreachable.
+// Non-GET requests pass through untouched.
+type CachingTransport struct {
+	// Transport is the underlying RoundTripper; defaults to
+	// http.DefaultTransport when nil.
+	Transport http.RoundTripper
+	Store     Store
+}
+
+// NewCachingTransport wraps http.DefaultTransport with a cache backed by store.
+func NewCachingTransport(store Store) *CachingTransport {
+	return &CachingTransport{Store: store}
+}
+
+func (t *CachingTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.transport().RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached, found := t.Store.Get(key)
+	if found && cached.matchesVary(req) && cached.fresh(time.Now()) {
+		return cached.toResponse(req), nil
+	}
+
+	revalidate := req.Clone(req.Context())
+	if found && cached.matchesVary(req) {
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			revalidate.Header.Set("If-None-Match", etag)
+		}
+		if lastMod := cached.Header.Get("Last-Modified"); lastMod != "" {
+			revalidate.Header.Set("If-Modified-Since", lastMod)
+		}
+	}
+
+	resp, err := t.transport().RoundTrip(revalidate)
+	if err != nil {
+		if found {
+			log.Printf("httpcache: %s unreachable, serving stale cache: %v", key, err)
+			return cached.toResponse(req), nil
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && found {
+		resp.Body.Close()
+		cached.FetchedAt = time.Now()
+		cached.ExpiresAt = computeExpiry(resp.Header, cached.FetchedAt)
+		if err := t.Store.Set(key, cached); err != nil {
+			log.Printf("httpcache: failed to persist revalidated entry for %s: %v", key, err)
+		}
+		return cached.toResponse(req), nil
+	}
+
+	if isCacheable(resp) {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr == nil {
+			entry := newEntry(req, resp, body, time.Now())
+			if err := t.Store.Set(key, entry); err != nil {
+				log.Printf("httpcache: failed to store entry for %s: %v", key, err)
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		} else {
+			log.Printf("httpcache: failed to read response body for %s: %v", key, readErr)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,156 @@
+// Package mqtt ingests weather/traffic telemetry published by city sensors
+// over MQTT, so the dashboard isn't limited to the single external weather
+// API — any sensor that can publish the documented JSON schema can feed it.
+package mqtt
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// Config configures an Ingestor's broker connection and subscribed topics.
+// WeatherTopic/TrafficTopic may use MQTT wildcards, e.g. "smartcity/+/weather"
+// to accept every sensor under the smartcity namespace.
+type Config struct {
+	BrokerURL    string
+	ClientID     string
+	Username     string
+	Password     string
+	KeepAlive    time.Duration // default 30s
+	WeatherTopic string        // default "smartcity/+/weather"
+	TrafficTopic string        // default "smartcity/+/traffic"
+}
+
+func (c Config) withDefaults() Config {
+	if c.KeepAlive <= 0 {
+		c.KeepAlive = 30 * time.Second
+	}
+	if c.WeatherTopic == "" {
+		c.WeatherTopic = "smartcity/+/weather"
+	}
+	if c.TrafficTopic == "" {
+		c.TrafficTopic = "smartcity/+/traffic"
+	}
+	return c
+}
+
+// Ingestor subscribes to MQTT topics and saves parsed messages via
+// domain.DataRepository. It keeps an in-memory registry of subscriptions so
+// OnConnect can replay every one of them after a broker reconnect, instead of
+// silently losing subscriptions paho doesn't restore on its own.
+type Ingestor struct {
+	cfg    Config
+	repo   domain.DataRepository
+	client paho.Client
+
+	mu            sync.Mutex
+	subscriptions map[string]paho.MessageHandler
+}
+
+// NewIngestor creates an Ingestor saving parsed messages through repo.
+func NewIngestor(cfg Config, repo domain.DataRepository) *Ingestor {
+	return &Ingestor{
+		cfg:           cfg.withDefaults(),
+		repo:          repo,
+		subscriptions: make(map[string]paho.MessageHandler),
+	}
+}
+
+// Start connects to the broker and subscribes to the configured weather and
+// traffic topics. It blocks only for the initial connection; message
+// handling and reconnects happen on paho's own goroutines.
+func (ing *Ingestor) Start(ctx context.Context) error {
+	opts := paho.NewClientOptions().
+		AddBroker(ing.cfg.BrokerURL).
+		SetClientID(ing.cfg.ClientID).
+		SetUsername(ing.cfg.Username).
+		SetPassword(ing.cfg.Password).
+		SetKeepAlive(ing.cfg.KeepAlive). // periodic PINGREQ so a dead link is detected within ~1.5x this interval
+		SetAutoReconnect(true).
+		SetOnConnectHandler(func(paho.Client) {
+			log.Println("mqtt: connected, replaying subscriptions")
+			ing.resubscribeAll()
+		}).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			log.Printf("mqtt: connection lost, will auto-reconnect: %v", err)
+		})
+
+	ing.client = paho.NewClient(opts)
+	ing.registerSubscription(ing.cfg.WeatherTopic, ing.handleWeatherMessage)
+	ing.registerSubscription(ing.cfg.TrafficTopic, ing.handleTrafficMessage)
+
+	token := ing.client.Connect()
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop disconnects from the broker, waiting up to 250ms to flush in-flight acks.
+func (ing *Ingestor) Stop() {
+	if ing.client != nil {
+		ing.client.Disconnect(250)
+	}
+}
+
+// registerSubscription adds topic to the in-memory registry so it survives
+// reconnects, then subscribes immediately if already connected.
+func (ing *Ingestor) registerSubscription(topic string, handler paho.MessageHandler) {
+	ing.mu.Lock()
+	ing.subscriptions[topic] = handler
+	client := ing.client
+	ing.mu.Unlock()
+
+	if client != nil && client.IsConnected() {
+		client.Subscribe(topic, 1, handler)
+	}
+}
+
+// resubscribeAll replays every registered subscription. Called from
+// SetOnConnectHandler, which fires on the initial connect and every
+// subsequent reconnect alike.
+func (ing *Ingestor) resubscribeAll() {
+	ing.mu.Lock()
+	defer ing.mu.Unlock()
+	for topic, handler := range ing.subscriptions {
+		if token := ing.client.Subscribe(topic, 1, handler); token.Wait() && token.Error() != nil {
+			log.Printf("mqtt: failed to subscribe to %s: %v", topic, token.Error())
+		}
+	}
+}
+
+func (ing *Ingestor) handleWeatherMessage(_ paho.Client, msg paho.Message) {
+	weather, err := parseWeatherMessage(msg.Topic(), msg.Payload())
+	if err != nil {
+		log.Printf("mqtt: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ing.repo.SaveWeatherData(ctx, weather); err != nil {
+		log.Printf("mqtt: failed to save weather from %s: %v", weather.Source, err)
+	}
+}
+
+func (ing *Ingestor) handleTrafficMessage(_ paho.Client, msg paho.Message) {
+	traffic, err := parseTrafficMessage(msg.Topic(), msg.Payload())
+	if err != nil {
+		log.Printf("mqtt: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ing.repo.SaveTrafficData(ctx, traffic); err != nil {
+		log.Printf("mqtt: failed to save traffic from %s: %v", traffic.Source, err)
+	}
+}
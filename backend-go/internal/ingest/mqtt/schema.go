@@ -0,0 +1,100 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// weatherPayload is the JSON schema published to a `smartcity/<sensorID>/weather`
+// topic. Fields mirror domain.Weather but stay independent so a malformed
+// sensor payload can't silently corrupt the domain type via field reuse.
+type weatherPayload struct {
+	City        string  `json:"city"`
+	Country     string  `json:"country"`
+	Temperature float64 `json:"temperature"`
+	FeelsLike   float64 `json:"feels_like"`
+	Humidity    int     `json:"humidity"`
+	Description string  `json:"description"`
+	WindSpeed   float64 `json:"wind_speed"`
+	Visibility  int     `json:"visibility"`
+	Pressure    int     `json:"pressure"`
+	AQI         int     `json:"aqi"`
+	Timestamp   *int64  `json:"timestamp,omitempty"` // unix seconds; defaults to receipt time
+}
+
+// trafficPayload is the JSON schema published to a `smartcity/<sensorID>/traffic` topic.
+type trafficPayload struct {
+	CongestionIndex float64 `json:"congestion_index"`
+	CongestionLevel string  `json:"congestion_level"`
+	AverageSpeed    float64 `json:"average_speed"`
+	FreeFlowSpeed   float64 `json:"free_flow_speed"`
+	IncidentCount   int     `json:"incident_count"`
+	Timestamp       *int64  `json:"timestamp,omitempty"`
+}
+
+// sensorIDFromTopic extracts the wildcard segment from a topic matching
+// `smartcity/<sensorID>/<kind>`, e.g. "smartcity/sensor-12/weather" -> "sensor-12".
+func sensorIDFromTopic(topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) >= 2 {
+		return parts[1]
+	}
+	return "unknown"
+}
+
+// parseWeatherMessage decodes a weather payload and tags it with the
+// publishing sensor via domain.Weather.Source, e.g. "mqtt:sensor-12".
+func parseWeatherMessage(topic string, body []byte) (domain.Weather, error) {
+	var p weatherPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return domain.Weather{}, fmt.Errorf("mqtt: invalid weather payload on %s: %w", topic, err)
+	}
+
+	ts := time.Now()
+	if p.Timestamp != nil {
+		ts = time.Unix(*p.Timestamp, 0)
+	}
+
+	return domain.Weather{
+		Temperature: p.Temperature,
+		FeelsLike:   p.FeelsLike,
+		Humidity:    p.Humidity,
+		Description: p.Description,
+		WindSpeed:   p.WindSpeed,
+		Visibility:  p.Visibility,
+		Pressure:    p.Pressure,
+		AQI:         p.AQI,
+		City:        p.City,
+		Country:     p.Country,
+		Timestamp:   ts,
+		Source:      "mqtt:" + sensorIDFromTopic(topic),
+	}, nil
+}
+
+// parseTrafficMessage decodes a traffic payload and tags it with the
+// publishing sensor via domain.Traffic.Source, e.g. "mqtt:sensor-12".
+func parseTrafficMessage(topic string, body []byte) (domain.Traffic, error) {
+	var p trafficPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return domain.Traffic{}, fmt.Errorf("mqtt: invalid traffic payload on %s: %w", topic, err)
+	}
+
+	ts := time.Now()
+	if p.Timestamp != nil {
+		ts = time.Unix(*p.Timestamp, 0)
+	}
+
+	return domain.Traffic{
+		CongestionIndex: p.CongestionIndex,
+		CongestionLevel: p.CongestionLevel,
+		AverageSpeed:    p.AverageSpeed,
+		FreeFlowSpeed:   p.FreeFlowSpeed,
+		IncidentCount:   p.IncidentCount,
+		Timestamp:       ts,
+		Source:          "mqtt:" + sensorIDFromTopic(topic),
+	}, nil
+}
@@ -0,0 +1,145 @@
+// Package log provides a small leveled logger with request-ID propagation,
+// used in place of the standard library's bare "log" package where it has
+// been adopted; packages that still call "log" directly log without IDs.
+// A request ID stashed on a context.Context (see WithRequestID) is echoed on
+// every line logged with that context, so a single request's log lines —
+// the incoming HTTP call, the provider fan-out it triggers, its DB writes —
+// can be grepped out of an otherwise interleaved log stream.
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Level is a logging severity, ordered from least to most severe.
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+// String renders the level the way it appears in log output, e.g. "INFO".
+func (l Level) String() string {
+	switch l {
+	case Debug:
+		return "DEBUG"
+	case Info:
+		return "INFO"
+	case Warn:
+		return "WARN"
+	case Error:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseLevel parses a level name case-insensitively (e.g. from GO_LOG_LEVEL),
+// defaulting to Info if name doesn't match a known level.
+func ParseLevel(name string) Level {
+	switch strings.ToLower(name) {
+	case "debug":
+		return Debug
+	case "warn":
+		return Warn
+	case "error":
+		return Error
+	default:
+		return Info
+	}
+}
+
+// Logger is a leveled logger. The zero value is not usable; construct one
+// with New.
+type Logger struct {
+	minLevel Level
+	std      *log.Logger
+}
+
+// New creates a Logger writing to out, filtering out anything below minLevel.
+func New(out io.Writer, minLevel Level) *Logger {
+	return &Logger{
+		minLevel: minLevel,
+		std:      log.New(out, "", log.LstdFlags),
+	}
+}
+
+var defaultLogger = New(os.Stdout, Info)
+
+// Default returns the process-wide logger used by packages that don't have
+// one threaded through via constructor injection.
+func Default() *Logger { return defaultLogger }
+
+// SetDefault replaces the process-wide logger, e.g. to raise verbosity from
+// GO_LOG_LEVEL at startup.
+func SetDefault(l *Logger) { defaultLogger = l }
+
+func (l *Logger) log(ctx context.Context, lvl Level, format string, args ...interface{}) {
+	if lvl < l.minLevel {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if id := RequestID(ctx); id != "" {
+		l.std.Printf("[%s] [req=%s] %s", lvl, id, msg)
+		return
+	}
+	l.std.Printf("[%s] %s", lvl, msg)
+}
+
+// Debugf logs at Debug level. ctx may be nil; pass context.Background() if
+// there's no request-scoped context at hand.
+func (l *Logger) Debugf(ctx context.Context, format string, args ...interface{}) {
+	l.log(ctx, Debug, format, args...)
+}
+
+// Infof logs at Info level.
+func (l *Logger) Infof(ctx context.Context, format string, args ...interface{}) {
+	l.log(ctx, Info, format, args...)
+}
+
+// Warnf logs at Warn level.
+func (l *Logger) Warnf(ctx context.Context, format string, args ...interface{}) {
+	l.log(ctx, Warn, format, args...)
+}
+
+// Errorf logs at Error level.
+func (l *Logger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	l.log(ctx, Error, format, args...)
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying id, retrievable via RequestID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID stashed on ctx by WithRequestID, or "" if
+// ctx is nil or carries none.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+var requestCounter uint64
+
+// NewRequestID generates a process-unique, human-scannable request ID. It's
+// deliberately not a UUID — nothing here needs uniqueness across processes,
+// just enough entropy to tell concurrent in-process requests apart in logs.
+func NewRequestID() string {
+	n := atomic.AddUint64(&requestCounter, 1)
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), n)
+}
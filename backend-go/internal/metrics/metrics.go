@@ -0,0 +1,91 @@
+// Package metrics exposes Prometheus/OpenMetrics gauges and counters for the
+// live weather, traffic, and AQI data this service collects, so operators can
+// alert on staleness or upstream provider health without a separate collector.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// WeatherTemperatureCelsius is the most recently observed temperature,
+	// labeled by the provider that served it.
+	WeatherTemperatureCelsius = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "smartcity_weather_temperature_celsius",
+		Help: "Current temperature in Celsius, by weather provider source.",
+	}, []string{"source"})
+
+	// WeatherAQI is the current US EPA AQI value, labeled by dominant pollutant.
+	WeatherAQI = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "smartcity_weather_aqi",
+		Help: "Current US EPA Air Quality Index, labeled by dominant pollutant.",
+	}, []string{"pollutant"})
+
+	// TrafficCongestionIndex is the current 0-100 city-wide congestion index.
+	TrafficCongestionIndex = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "smartcity_traffic_congestion_index",
+		Help: "Current city-wide traffic congestion index (0-100).",
+	})
+
+	// TrafficIncidentCount is the current incident count, labeled by type.
+	TrafficIncidentCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "smartcity_traffic_incident_count",
+		Help: "Current number of active traffic incidents, by type.",
+	}, []string{"type"})
+
+	// ProviderRequestTotal counts upstream requests per provider and outcome.
+	ProviderRequestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "smartcity_provider_request_total",
+		Help: "Total upstream provider requests, by provider and status (success/error).",
+	}, []string{"provider", "status"})
+
+	// ProviderRequestDuration tracks upstream request latency per provider.
+	ProviderRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "smartcity_provider_request_duration_seconds",
+		Help:    "Upstream provider request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"provider"})
+
+	// ProviderLastSuccessTimestamp is the unix timestamp of each provider's
+	// last successful fetch, so operators can alert on stale data per source.
+	ProviderLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "smartcity_provider_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful fetch, by provider.",
+	}, []string{"provider"})
+
+	// PredictionCacheTotal counts PredictionCache lookups, by result.
+	PredictionCacheTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "smartcity_prediction_cache_total",
+		Help: "Total prediction cache lookups, by result (hit/miss).",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		WeatherTemperatureCelsius,
+		WeatherAQI,
+		TrafficCongestionIndex,
+		TrafficIncidentCount,
+		ProviderRequestTotal,
+		ProviderRequestDuration,
+		ProviderLastSuccessTimestamp,
+		PredictionCacheTotal,
+	)
+}
+
+// ObserveProviderRequest records the outcome of a single upstream provider
+// call: a success/error counter, a latency observation, and — on success —
+// the last-success timestamp gauge used for staleness alerting.
+func ObserveProviderRequest(provider string, err error, duration time.Duration) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	ProviderRequestTotal.WithLabelValues(provider, status).Inc()
+	ProviderRequestDuration.WithLabelValues(provider).Observe(duration.Seconds())
+	if err == nil {
+		ProviderLastSuccessTimestamp.WithLabelValues(provider).Set(float64(time.Now().Unix()))
+	}
+}
@@ -0,0 +1,53 @@
+// Package repository composes domain.DataRepository implementations so
+// different data kinds can live in the backend best suited to them.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// HybridRepository routes weather/traffic telemetry to one DataRepository
+// (typically influxdb.InfluxRepository, for downsampling/retention) and
+// prediction logs to another (typically postgres.PostgresRepository, since
+// they're relational request/response records, not a time series).
+type HybridRepository struct {
+	telemetry domain.DataRepository
+	logs      domain.DataRepository
+}
+
+// NewHybridRepository creates a HybridRepository backed by telemetry for
+// weather/traffic and logs for prediction logs.
+func NewHybridRepository(telemetry, logs domain.DataRepository) *HybridRepository {
+	return &HybridRepository{telemetry: telemetry, logs: logs}
+}
+
+func (r *HybridRepository) SaveWeatherData(ctx context.Context, data domain.Weather) error {
+	return r.telemetry.SaveWeatherData(ctx, data)
+}
+
+func (r *HybridRepository) SaveTrafficData(ctx context.Context, data domain.Traffic) error {
+	return r.telemetry.SaveTrafficData(ctx, data)
+}
+
+func (r *HybridRepository) GetHistoricalWeather(ctx context.Context, from, to time.Time) ([]domain.Weather, error) {
+	return r.telemetry.GetHistoricalWeather(ctx, from, to)
+}
+
+func (r *HybridRepository) GetHistoricalTraffic(ctx context.Context, from, to time.Time) ([]domain.Traffic, error) {
+	return r.telemetry.GetHistoricalTraffic(ctx, from, to)
+}
+
+func (r *HybridRepository) SavePredictionLog(ctx context.Context, req domain.PredictionRequest, resp domain.PredictionResponse) error {
+	return r.logs.SavePredictionLog(ctx, req, resp)
+}
+
+// Health reports unhealthy if either backend is unhealthy.
+func (r *HybridRepository) Health(ctx context.Context) error {
+	if err := r.telemetry.Health(ctx); err != nil {
+		return err
+	}
+	return r.logs.Health(ctx)
+}
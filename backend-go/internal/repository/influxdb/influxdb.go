@@ -0,0 +1,228 @@
+// Package influxdb implements domain.DataRepository on top of InfluxDB v2,
+// storing weather/traffic samples as tagged time-series points instead of
+// relational rows. Unlike Postgres inserts, this gets downsampling and
+// retention policies "for free" from InfluxDB's bucket configuration.
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/api/query"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// InfluxRepository implements domain.DataRepository using InfluxDB v2 as the
+// telemetry store. SavePredictionLog/Health are implemented too so it can be
+// used standalone; pair it with postgres.PostgresRepository behind
+// repository.HybridRepository to keep prediction logs relational.
+type InfluxRepository struct {
+	client   influxdb2.Client
+	writeAPI api.WriteAPIBlocking
+	queryAPI api.QueryAPI
+	bucket   string
+}
+
+// NewInfluxRepository connects to the InfluxDB v2 server at url, writing to
+// and querying org/bucket.
+func NewInfluxRepository(url, token, org, bucket string) *InfluxRepository {
+	client := influxdb2.NewClient(url, token)
+	return &InfluxRepository{
+		client:   client,
+		writeAPI: client.WriteAPIBlocking(org, bucket),
+		queryAPI: client.QueryAPI(org),
+		bucket:   bucket,
+	}
+}
+
+// Close releases the underlying InfluxDB client's connections.
+func (r *InfluxRepository) Close() {
+	r.client.Close()
+}
+
+// SaveWeatherData writes a weather reading as a "weather" measurement point,
+// tagged by city/country/source so queries can filter without scanning fields.
+func (r *InfluxRepository) SaveWeatherData(ctx context.Context, data domain.Weather) error {
+	point := influxdb2.NewPoint(
+		"weather",
+		map[string]string{
+			"city":    data.City,
+			"country": data.Country,
+			"source":  data.Source,
+		},
+		map[string]interface{}{
+			"temperature": data.Temperature,
+			"feels_like":  data.FeelsLike,
+			"humidity":    data.Humidity,
+			"wind_speed":  data.WindSpeed,
+			"visibility":  data.Visibility,
+			"pressure":    data.Pressure,
+			"aqi":         data.AQI,
+		},
+		data.Timestamp,
+	)
+
+	if err := r.writeAPI.WritePoint(ctx, point); err != nil {
+		return fmt.Errorf("influxdb: failed to write weather point: %w", err)
+	}
+	return nil
+}
+
+// SaveTrafficData writes a traffic reading as a "traffic" measurement point,
+// tagged by congestion level so Flux queries can filter on it without a scan.
+func (r *InfluxRepository) SaveTrafficData(ctx context.Context, data domain.Traffic) error {
+	point := influxdb2.NewPoint(
+		"traffic",
+		map[string]string{
+			"congestion_level": data.CongestionLevel,
+		},
+		map[string]interface{}{
+			"congestion_index": data.CongestionIndex,
+			"average_speed":    data.AverageSpeed,
+			"free_flow_speed":  data.FreeFlowSpeed,
+			"incident_count":   data.IncidentCount,
+		},
+		data.Timestamp,
+	)
+
+	if err := r.writeAPI.WritePoint(ctx, point); err != nil {
+		return fmt.Errorf("influxdb: failed to write traffic point: %w", err)
+	}
+	return nil
+}
+
+// GetHistoricalWeather runs a Flux range query over the "weather" measurement.
+func (r *InfluxRepository) GetHistoricalWeather(ctx context.Context, from, to time.Time) ([]domain.Weather, error) {
+	flux := fmt.Sprintf(`
+		from(bucket: %q)
+			|> range(start: %s, stop: %s)
+			|> filter(fn: (r) => r._measurement == "weather")
+			|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+			|> sort(columns: ["_time"], desc: true)
+			|> limit(n: 100)
+	`, r.bucket, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+
+	result, err := r.queryAPI.Query(ctx, flux)
+	if err != nil {
+		return nil, fmt.Errorf("influxdb: failed to query weather history: %w", err)
+	}
+	defer result.Close()
+
+	var out []domain.Weather
+	for result.Next() {
+		rec := result.Record()
+		out = append(out, domain.Weather{
+			Temperature: fieldFloat(rec, "temperature"),
+			FeelsLike:   fieldFloat(rec, "feels_like"),
+			Humidity:    int(fieldFloat(rec, "humidity")),
+			WindSpeed:   fieldFloat(rec, "wind_speed"),
+			Visibility:  int(fieldFloat(rec, "visibility")),
+			Pressure:    int(fieldFloat(rec, "pressure")),
+			AQI:         int(fieldFloat(rec, "aqi")),
+			City:        fieldString(rec, "city"),
+			Country:     fieldString(rec, "country"),
+			Source:      fieldString(rec, "source"),
+			Timestamp:   rec.Time(),
+		})
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("influxdb: weather history query error: %w", result.Err())
+	}
+	return out, nil
+}
+
+// GetHistoricalTraffic runs a Flux range query over the "traffic" measurement.
+func (r *InfluxRepository) GetHistoricalTraffic(ctx context.Context, from, to time.Time) ([]domain.Traffic, error) {
+	flux := fmt.Sprintf(`
+		from(bucket: %q)
+			|> range(start: %s, stop: %s)
+			|> filter(fn: (r) => r._measurement == "traffic")
+			|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+			|> sort(columns: ["_time"], desc: true)
+			|> limit(n: 100)
+	`, r.bucket, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+
+	result, err := r.queryAPI.Query(ctx, flux)
+	if err != nil {
+		return nil, fmt.Errorf("influxdb: failed to query traffic history: %w", err)
+	}
+	defer result.Close()
+
+	var out []domain.Traffic
+	for result.Next() {
+		rec := result.Record()
+		out = append(out, domain.Traffic{
+			CongestionIndex: fieldFloat(rec, "congestion_index"),
+			CongestionLevel: fieldString(rec, "congestion_level"),
+			AverageSpeed:    fieldFloat(rec, "average_speed"),
+			FreeFlowSpeed:   fieldFloat(rec, "free_flow_speed"),
+			IncidentCount:   int(fieldFloat(rec, "incident_count")),
+			Timestamp:       rec.Time(),
+		})
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("influxdb: traffic history query error: %w", result.Err())
+	}
+	return out, nil
+}
+
+// Health pings the InfluxDB server.
+func (r *InfluxRepository) Health(ctx context.Context) error {
+	ok, err := r.client.Ping(ctx)
+	if err != nil {
+		return fmt.Errorf("influxdb: health check failed: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("influxdb: health check returned not-ready")
+	}
+	return nil
+}
+
+// SavePredictionLog writes a prediction request/response as a point too, so
+// InfluxRepository alone still satisfies domain.DataRepository. Callers who
+// want prediction logs in Postgres instead should use
+// repository.HybridRepository.
+func (r *InfluxRepository) SavePredictionLog(ctx context.Context, req domain.PredictionRequest, resp domain.PredictionResponse) error {
+	point := influxdb2.NewPoint(
+		"prediction_logs",
+		map[string]string{
+			"is_mock": fmt.Sprintf("%v", resp.IsMock),
+		},
+		map[string]interface{}{
+			"prediction":       resp.Prediction,
+			"confidence_score": resp.ConfidenceScore,
+			"aqi_prediction":   resp.AQIPrediction,
+			"traffic_index":    resp.TrafficIndex,
+			"query":            req.Query,
+		},
+		time.Now(),
+	)
+
+	if err := r.writeAPI.WritePoint(ctx, point); err != nil {
+		return fmt.Errorf("influxdb: failed to write prediction log point: %w", err)
+	}
+	return nil
+}
+
+// fieldFloat reads a numeric field/tag from a Flux record, defaulting to 0.
+func fieldFloat(rec *query.FluxRecord, key string) float64 {
+	switch v := rec.ValueByKey(key).(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	return 0
+}
+
+// fieldString reads a string field/tag from a Flux record, defaulting to "".
+func fieldString(rec *query.FluxRecord, key string) string {
+	v, _ := rec.ValueByKey(key).(string)
+	return v
+}
@@ -70,3 +70,49 @@ func (r *MockRepository) Health(ctx context.Context) error {
 func (r *MockRepository) SavePredictionLog(ctx context.Context, req domain.PredictionRequest, resp domain.PredictionResponse) error {
 	return nil
 }
+
+// MockTrafficHistoryRepository implements domain.TrafficHistoryRepository for
+// testing/demo mode, when no database connection is available.
+type MockTrafficHistoryRepository struct{}
+
+// NewMockTrafficHistoryRepository creates a new mock traffic history repository.
+func NewMockTrafficHistoryRepository() *MockTrafficHistoryRepository {
+	return &MockTrafficHistoryRepository{}
+}
+
+// SaveSnapshot is a no-op in mock mode.
+func (r *MockTrafficHistoryRepository) SaveSnapshot(ctx context.Context, snapshot domain.Traffic) error {
+	return nil
+}
+
+// History returns a single mock bucket in mock mode.
+func (r *MockTrafficHistoryRepository) History(ctx context.Context, from, to time.Time, road string, bucket time.Duration) ([]domain.TrafficHistoryBucket, error) {
+	name := road
+	if name == "" {
+		name = "Al-Farabi Ave"
+	}
+	return []domain.TrafficHistoryBucket{
+		{
+			BucketStart:        time.Now().Add(-1 * time.Hour),
+			RoadName:           name,
+			AvgCongestion:      0.45,
+			AvgSpeed:           38.0,
+			AvgCongestionIndex: 45.0,
+			AvgIncidentCount:   1,
+			SampleCount:        1,
+		},
+	}, nil
+}
+
+// Typical returns a single mock typical-congestion reading in mock mode.
+func (r *MockTrafficHistoryRepository) Typical(ctx context.Context, dayOfWeek time.Weekday, hour int) (domain.TrafficTypical, error) {
+	return domain.TrafficTypical{
+		DayOfWeek:           dayOfWeek.String(),
+		Hour:                hour,
+		MeanCongestion:      0.45,
+		StddevCongestion:    0.1,
+		MeanCongestionIndex: 45.0,
+		MeanIncidentCount:   1,
+		SampleCount:         1,
+	}, nil
+}
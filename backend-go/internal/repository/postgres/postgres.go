@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/metrics"
 )
 
 // PostgresRepository implements domain.DataRepository
@@ -28,10 +29,12 @@ func (r *PostgresRepository) SaveWeatherData(ctx context.Context, data domain.We
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
+	start := time.Now()
 	_, err := r.pool.Exec(ctx, query,
 		data.Temperature, data.FeelsLike, data.Humidity, data.Description, data.Icon,
 		data.WindSpeed, data.Visibility, data.Pressure, data.AQI, data.City, data.Country, data.Timestamp,
 	)
+	metrics.ObserveProviderRequest("postgres-save-weather", err, time.Since(start))
 	if err != nil {
 		return fmt.Errorf("postgres: failed to save weather data: %w", err)
 	}
@@ -48,10 +51,12 @@ func (r *PostgresRepository) SaveTrafficData(ctx context.Context, data domain.Tr
 		) VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
+	start := time.Now()
 	_, err := r.pool.Exec(ctx, query,
 		data.CongestionIndex, data.CongestionLevel, data.AverageSpeed, data.FreeFlowSpeed,
 		data.IncidentCount, data.Timestamp,
 	)
+	metrics.ObserveProviderRequest("postgres-save-traffic", err, time.Since(start))
 	if err != nil {
 		return fmt.Errorf("postgres: failed to save traffic data: %w", err)
 	}
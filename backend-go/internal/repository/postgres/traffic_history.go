@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/metrics"
+)
+
+// TrafficHistoryRepository implements domain.TrafficHistoryRepository against
+// the traffic_segment_history table (see migrations/0001_traffic_history.sql).
+// That migration creates a plain indexed table; it becomes a TimescaleDB
+// hypertable only if the commented-out create_hypertable call at the end of
+// the migration is run by hand. This repository only issues portable SQL,
+// so it works against either.
+type TrafficHistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTrafficHistoryRepository creates a traffic history repository.
+func NewTrafficHistoryRepository(pool *pgxpool.Pool) *TrafficHistoryRepository {
+	return &TrafficHistoryRepository{pool: pool}
+}
+
+// SaveSnapshot persists every RoadSegment in snapshot as one row each,
+// sharing snapshot.Timestamp.
+func (r *TrafficHistoryRepository) SaveSnapshot(ctx context.Context, snapshot domain.Traffic) error {
+	if len(snapshot.RoadSegments) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO traffic_segment_history (
+			timestamp, road_name, congestion, speed, free_flow, congestion_index, incident_count
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	start := time.Now()
+	batch := &pgx.Batch{}
+	for _, seg := range snapshot.RoadSegments {
+		batch.Queue(query, snapshot.Timestamp, seg.Name, seg.Congestion, seg.Speed, seg.FreeFlow,
+			snapshot.CongestionIndex, snapshot.IncidentCount)
+	}
+	br := r.pool.SendBatch(ctx, batch)
+	var batchErr error
+	for range snapshot.RoadSegments {
+		if _, err := br.Exec(); err != nil {
+			batchErr = err
+			break
+		}
+	}
+	closeErr := br.Close()
+	if batchErr == nil {
+		batchErr = closeErr
+	}
+	metrics.ObserveProviderRequest("postgres-save-traffic-history", batchErr, time.Since(start))
+	if batchErr != nil {
+		return fmt.Errorf("postgres: failed to save traffic history: %w", batchErr)
+	}
+
+	return nil
+}
+
+// History returns bucketed averages between from and to, optionally filtered
+// to a single road name. Bucketing uses a portable floor-to-interval
+// expression (not Timescale's time_bucket()) so it works whether or not the
+// extension is installed.
+func (r *TrafficHistoryRepository) History(ctx context.Context, from, to time.Time, road string, bucket time.Duration) ([]domain.TrafficHistoryBucket, error) {
+	bucketSeconds := bucket.Seconds()
+	if bucketSeconds <= 0 {
+		bucketSeconds = 900 // 15m default, matches GetCurrentTraffic's cache TTL
+	}
+
+	query := `
+		SELECT
+			to_timestamp(floor(extract(epoch from timestamp) / $1) * $1) AS bucket_start,
+			road_name,
+			AVG(congestion) AS avg_congestion,
+			AVG(speed) AS avg_speed,
+			AVG(congestion_index) AS avg_congestion_index,
+			AVG(incident_count) AS avg_incident_count,
+			COUNT(*) AS sample_count
+		FROM traffic_segment_history
+		WHERE timestamp BETWEEN $2 AND $3
+		  AND ($4 = '' OR road_name = $4)
+		GROUP BY bucket_start, road_name
+		ORDER BY bucket_start DESC, road_name
+	`
+
+	rows, err := r.pool.Query(ctx, query, bucketSeconds, from, to, road)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: failed to query traffic history: %w", err)
+	}
+	defer rows.Close()
+
+	var results []domain.TrafficHistoryBucket
+	for rows.Next() {
+		var b domain.TrafficHistoryBucket
+		if err := rows.Scan(&b.BucketStart, &b.RoadName, &b.AvgCongestion, &b.AvgSpeed,
+			&b.AvgCongestionIndex, &b.AvgIncidentCount, &b.SampleCount); err != nil {
+			return nil, fmt.Errorf("postgres: failed to scan traffic history row: %w", err)
+		}
+		results = append(results, b)
+	}
+
+	return results, nil
+}
+
+// Typical returns the long-run mean+stddev congestion recorded for the given
+// day-of-week and hour-of-day, across all history, averaged over every road.
+func (r *TrafficHistoryRepository) Typical(ctx context.Context, dayOfWeek time.Weekday, hour int) (domain.TrafficTypical, error) {
+	query := `
+		SELECT
+			AVG(congestion) AS mean_congestion,
+			COALESCE(STDDEV_POP(congestion), 0) AS stddev_congestion,
+			AVG(congestion_index) AS mean_congestion_index,
+			AVG(incident_count) AS mean_incident_count,
+			COUNT(*) AS sample_count
+		FROM traffic_segment_history
+		WHERE EXTRACT(DOW FROM timestamp) = $1
+		  AND EXTRACT(HOUR FROM timestamp) = $2
+	`
+
+	var typical domain.TrafficTypical
+	typical.DayOfWeek = dayOfWeek.String()
+	typical.Hour = hour
+
+	// Postgres EXTRACT(DOW ...) returns 0=Sunday..6=Saturday, matching
+	// time.Weekday's numbering exactly.
+	row := r.pool.QueryRow(ctx, query, int(dayOfWeek), hour)
+	if err := row.Scan(&typical.MeanCongestion, &typical.StddevCongestion,
+		&typical.MeanCongestionIndex, &typical.MeanIncidentCount, &typical.SampleCount); err != nil {
+		return domain.TrafficTypical{}, fmt.Errorf("postgres: failed to query typical congestion: %w", err)
+	}
+
+	return typical, nil
+}
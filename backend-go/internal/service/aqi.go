@@ -0,0 +1,180 @@
+package service
+
+import (
+	"math"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// aqiBreakpoint is one row of an EPA piecewise-linear AQI breakpoint table:
+// concentrations in [cLow, cHigh] map linearly onto AQI values [iLow, iHigh].
+type aqiBreakpoint struct {
+	cLow, cHigh float64
+	iLow, iHigh int
+}
+
+// pm25Breakpoints uses the February 2024 revised breakpoints (88 FR 5558).
+var pm25Breakpoints = []aqiBreakpoint{
+	{0.0, 9.0, 0, 50},
+	{9.1, 35.4, 51, 100},
+	{35.5, 55.4, 101, 150},
+	{55.5, 125.4, 151, 200},
+	{125.5, 225.4, 201, 300},
+	{225.5, 325.4, 301, 400},
+	{325.5, 500.4, 401, 500},
+}
+
+// pm10Breakpoints: µg/m³, truncated to integer.
+var pm10Breakpoints = []aqiBreakpoint{
+	{0, 54, 0, 50},
+	{55, 154, 51, 100},
+	{155, 254, 101, 150},
+	{255, 354, 151, 200},
+	{355, 424, 201, 300},
+	{425, 604, 301, 500},
+}
+
+// o3_8hBreakpoints: ppm, truncated to 0.001 ppm. EPA's table stops at 300
+// AQI for 8-hour ozone, so this sub-index is clamped at 300; above 0.200 ppm
+// the 1-hour table is scored as well, alongside it.
+var o3_8hBreakpoints = []aqiBreakpoint{
+	{0.000, 0.054, 0, 50},
+	{0.055, 0.070, 51, 100},
+	{0.071, 0.085, 101, 150},
+	{0.086, 0.105, 151, 200},
+	{0.106, 0.200, 201, 300},
+}
+
+// o3_1hBreakpoints: ppm, scored in addition to the (clamped) 8-hour table
+// for ozone above 0.200 ppm.
+var o3_1hBreakpoints = []aqiBreakpoint{
+	{0.125, 0.164, 101, 150},
+	{0.165, 0.204, 151, 200},
+	{0.205, 0.404, 201, 300},
+	{0.405, 0.504, 301, 400},
+	{0.505, 0.604, 401, 500},
+}
+
+// no2Breakpoints: ppb, truncated to integer.
+var no2Breakpoints = []aqiBreakpoint{
+	{0, 53, 0, 50},
+	{54, 100, 51, 100},
+	{101, 360, 101, 150},
+	{361, 649, 151, 200},
+	{650, 1249, 201, 300},
+	{1250, 1649, 301, 400},
+	{1650, 2049, 401, 500},
+}
+
+// so2Breakpoints: ppb, truncated to integer.
+var so2Breakpoints = []aqiBreakpoint{
+	{0, 35, 0, 50},
+	{36, 75, 51, 100},
+	{76, 185, 101, 150},
+	{186, 304, 151, 200},
+	{305, 604, 201, 300},
+	{605, 804, 301, 400},
+	{805, 1004, 401, 500},
+}
+
+// coBreakpoints: ppm, truncated to 0.1 ppm.
+var coBreakpoints = []aqiBreakpoint{
+	{0.0, 4.4, 0, 50},
+	{4.5, 9.4, 51, 100},
+	{9.5, 12.4, 101, 150},
+	{12.5, 15.4, 151, 200},
+	{15.5, 30.4, 201, 300},
+	{30.5, 40.4, 301, 400},
+	{40.5, 50.4, 401, 500},
+}
+
+// subIndex computes I = (Ihi-Ilo)/(Chi-Clo) * (C-Clo) + Ilo for the matching
+// breakpoint row, truncating to max(table) for concentrations above range.
+func subIndex(c float64, table []aqiBreakpoint) int {
+	for _, b := range table {
+		if c >= b.cLow && c <= b.cHigh {
+			v := float64(b.iHigh-b.iLow)/(b.cHigh-b.cLow)*(c-b.cLow) + float64(b.iLow)
+			return int(math.Round(v))
+		}
+	}
+	if len(table) > 0 && c > table[len(table)-1].cHigh {
+		return table[len(table)-1].iHigh
+	}
+	return 0
+}
+
+// truncate rounds c down to the given number of decimal places, as 40 CFR
+// Part 58 Appendix G requires before breakpoint lookup (e.g. PM2.5 to 0.1
+// µg/m³, PM10/NO2/SO2 to integer, O3 to 0.001 ppm, CO to 0.1 ppm).
+func truncate(c float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Floor(c*factor) / factor
+}
+
+// pollutantConcentrations holds the raw readings used to compute the
+// multi-pollutant AQI. Fields are nil when Open-Meteo didn't report them.
+type pollutantConcentrations struct {
+	PM25 *float64 // µg/m³
+	PM10 *float64 // µg/m³
+	O3   *float64 // ppm (already converted from µg/m³)
+	NO2  *float64 // ppb (already converted from µg/m³)
+	SO2  *float64 // ppb (already converted from µg/m³)
+	CO   *float64 // ppm (already converted from µg/m³)
+}
+
+// computeEPAAQI computes the full US EPA AQI across every reported
+// pollutant: a sub-index per pollutant via piecewise-linear interpolation
+// over pollutant-specific breakpoint tables, then AQI = max(subIndex_i),
+// with DominantPollutant naming whichever sub-index produced that max.
+func computeEPAAQI(c pollutantConcentrations) (aqi int, dominant string, subIndices []domain.PollutantSubIndex) {
+	add := func(name string, value *float64, places int, table []aqiBreakpoint) {
+		if value == nil {
+			return
+		}
+		truncated := truncate(*value, places)
+		idx := subIndex(truncated, table)
+		subIndices = append(subIndices, domain.PollutantSubIndex{
+			Pollutant:     name,
+			Concentration: truncated,
+			SubIndex:      idx,
+		})
+	}
+
+	add("pm25", c.PM25, 1, pm25Breakpoints)
+	add("pm10", c.PM10, 0, pm10Breakpoints)
+	add("o3_8h", c.O3, 3, o3_8hBreakpoints)
+	if c.O3 != nil && truncate(*c.O3, 3) > 0.200 {
+		add("o3_1h", c.O3, 3, o3_1hBreakpoints)
+	}
+	add("no2", c.NO2, 0, no2Breakpoints)
+	add("so2", c.SO2, 0, so2Breakpoints)
+	add("co", c.CO, 1, coBreakpoints)
+
+	for _, s := range subIndices {
+		if s.SubIndex > aqi {
+			aqi = s.SubIndex
+			dominant = s.Pollutant
+		}
+	}
+
+	return aqi, dominant, subIndices
+}
+
+// ugm3ToPPM converts a µg/m³ concentration to ppm at standard conditions
+// (25°C, 1 atm) given the pollutant's molar mass in g/mol.
+func ugm3ToPPM(ugm3, molarMassGPerMol float64) float64 {
+	const molarVolumeLPerMol = 24.45 // L/mol at 25°C, 1 atm
+	return (ugm3 * molarVolumeLPerMol) / (molarMassGPerMol * 1000)
+}
+
+// ugm3ToPPB is ugm3ToPPM scaled to parts-per-billion, used for NO2/SO2 tables.
+func ugm3ToPPB(ugm3, molarMassGPerMol float64) float64 {
+	return ugm3ToPPM(ugm3, molarMassGPerMol) * 1000
+}
+
+const (
+	molarMassO3  = 48.00
+	molarMassCO  = 28.01
+	molarMassNO2 = 46.0055
+	molarMassSO2 = 64.066
+)
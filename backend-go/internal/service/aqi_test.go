@@ -0,0 +1,138 @@
+package service
+
+import "testing"
+
+func TestSubIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		c    float64
+		want int
+	}{
+		{"bottom of first breakpoint", 0.0, 0},
+		{"top of first breakpoint", 9.0, 50},
+		{"bottom of second breakpoint", 9.1, 51},
+		{"top of table", 500.4, 500},
+		{"above top of table clamps to max", 10000, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subIndex(tt.c, pm25Breakpoints)
+			if got != tt.want {
+				t.Errorf("subIndex(%v) = %d, want %d", tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubIndex_NO2BreakpointBoundaries(t *testing.T) {
+	// Regression test for a transposed boundary between the last two NO2
+	// tiers: 1700 ppb must score in the 401-500 (Hazardous) band, not fall
+	// into a gap and get interpolated into 301-400.
+	tests := []struct {
+		name string
+		c    float64
+		want int
+	}{
+		{"top of 301-400 tier", 1649, 400},
+		{"bottom of 401-500 tier", 1650, 401},
+		{"within 401-500 tier", 1700, 413},
+		{"top of table", 2049, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subIndex(tt.c, no2Breakpoints)
+			if got != tt.want {
+				t.Errorf("subIndex(%v, no2Breakpoints) = %d, want %d", tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		c      float64
+		places int
+		want   float64
+	}{
+		{12.39, 1, 12.3},
+		{12.39, 0, 12},
+		{0.0549, 3, 0.054},
+		{9.0, 1, 9.0},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.c, tt.places); got != tt.want {
+			t.Errorf("truncate(%v, %d) = %v, want %v", tt.c, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestComputeEPAAQI_DominantPollutantIsMaxSubIndex(t *testing.T) {
+	pm25 := 10.0  // sub-index ~53 (second breakpoint)
+	pm10 := 300.0 // sub-index ~173 (fourth breakpoint) - dominant
+	co := 2.0     // sub-index well under 100
+
+	aqi, dominant, subIndices := computeEPAAQI(pollutantConcentrations{
+		PM25: &pm25,
+		PM10: &pm10,
+		CO:   &co,
+	})
+
+	if dominant != "pm10" {
+		t.Errorf("dominant = %q, want %q", dominant, "pm10")
+	}
+	wantAQI := subIndex(300.0, pm10Breakpoints)
+	if aqi != wantAQI {
+		t.Errorf("aqi = %d, want %d", aqi, wantAQI)
+	}
+	if len(subIndices) != 3 {
+		t.Errorf("len(subIndices) = %d, want 3 (one per reported pollutant)", len(subIndices))
+	}
+}
+
+func TestComputeEPAAQI_NilPollutantsAreSkipped(t *testing.T) {
+	pm25 := 5.0
+	aqi, dominant, subIndices := computeEPAAQI(pollutantConcentrations{PM25: &pm25})
+
+	if len(subIndices) != 1 {
+		t.Fatalf("len(subIndices) = %d, want 1", len(subIndices))
+	}
+	if dominant != "pm25" {
+		t.Errorf("dominant = %q, want %q", dominant, "pm25")
+	}
+	if aqi != subIndices[0].SubIndex {
+		t.Errorf("aqi = %d, want %d", aqi, subIndices[0].SubIndex)
+	}
+}
+
+func TestComputeEPAAQI_Ozone1hTableOnlyUsedAboveThreshold(t *testing.T) {
+	belowThreshold := 0.150 // stays on the 8-hour table
+	_, _, subIndices := computeEPAAQI(pollutantConcentrations{O3: &belowThreshold})
+	for _, s := range subIndices {
+		if s.Pollutant == "o3_1h" {
+			t.Errorf("o3_1h sub-index present for a concentration below the 0.200 ppm threshold")
+		}
+	}
+
+	aboveThreshold := 0.250 // should add both o3_8h (clamped) and o3_1h
+	_, _, subIndices = computeEPAAQI(pollutantConcentrations{O3: &aboveThreshold})
+	found := false
+	for _, s := range subIndices {
+		if s.Pollutant == "o3_1h" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("o3_1h sub-index missing for a concentration above the 0.200 ppm threshold")
+	}
+}
+
+func TestUgm3ToPPMAndPPB(t *testing.T) {
+	ppm := ugm3ToPPM(100, molarMassO3)
+	if ppm <= 0 {
+		t.Errorf("ugm3ToPPM returned non-positive value: %v", ppm)
+	}
+	ppb := ugm3ToPPB(100, molarMassO3)
+	if ppb != ppm*1000 {
+		t.Errorf("ugm3ToPPB(%v) = %v, want %v (ugm3ToPPM * 1000)", 100, ppb, ppm*1000)
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// replayBufferSize is how many past snapshots a newly-connected subscriber
+// can recover via Last-Event-ID, so a brief reconnect doesn't lose data.
+const replayBufferSize = 5
+
+// subscriberBufferSize bounds how far a slow consumer can lag before it is
+// dropped instead of blocking the broadcast loop.
+const subscriberBufferSize = 4
+
+// DashboardSnapshot is one broadcast tick, numbered so SSE clients can resume
+// via Last-Event-ID after a reconnect.
+type DashboardSnapshot struct {
+	ID   int64
+	Data domain.DashboardData
+}
+
+// Broker runs a single ticker that fetches weather+traffic once per tick and
+// fans the result out to every subscriber, so N connected clients no longer
+// multiply the upstream API calls DashboardService.GetDashboardData makes.
+type Broker struct {
+	dashboardSvc *DashboardService
+	interval     time.Duration
+
+	mu          sync.Mutex
+	subscribers map[int]chan DashboardSnapshot
+	nextSubID   int
+	replay      []DashboardSnapshot
+	nextSeq     int64
+	closed      bool
+
+	// droppedSlowConsumers counts subscribers dropped for falling behind. It
+	// is kept on the Broker only; nothing exports it to /metrics.
+	droppedSlowConsumers int64
+}
+
+// NewBroker creates a Broker that ticks every interval (default 30s when 0).
+func NewBroker(dashboardSvc *DashboardService, interval time.Duration) *Broker {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &Broker{
+		dashboardSvc: dashboardSvc,
+		interval:     interval,
+		subscribers:  make(map[int]chan DashboardSnapshot),
+	}
+}
+
+// Run starts the ticker loop. It blocks until ctx is cancelled (and then calls
+// Shutdown), so callers should run it in its own goroutine. Calling Shutdown
+// directly does not end the loop: later ticks still fetch dashboard data but
+// publish nothing.
+func (b *Broker) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	// Publish an initial snapshot immediately so the first subscriber doesn't
+	// wait a full interval for its first event.
+	b.tick(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.Shutdown()
+			return
+		case <-ticker.C:
+			b.tick(ctx)
+		}
+	}
+}
+
+func (b *Broker) tick(ctx context.Context) {
+	data, err := b.dashboardSvc.GetDashboardData(ctx)
+	if err != nil {
+		log.Printf("broker: dashboard fetch failed, skipping tick: %v", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.nextSeq++
+	snapshot := DashboardSnapshot{ID: b.nextSeq, Data: data}
+
+	b.replay = append(b.replay, snapshot)
+	if len(b.replay) > replayBufferSize {
+		b.replay = b.replay[len(b.replay)-replayBufferSize:]
+	}
+
+	for id, ch := range b.subscribers {
+		select {
+		case ch <- snapshot:
+		default:
+			// Slow consumer: drop it rather than block the whole fan-out.
+			log.Printf("broker: dropping slow subscriber %d", id)
+			b.droppedSlowConsumers++
+			close(ch)
+			delete(b.subscribers, id)
+		}
+	}
+}
+
+// Subscribe registers a new subscriber and returns its channel plus any
+// buffered snapshots with ID greater than lastEventID (IDs start at 1, so 0
+// replays the whole buffer).
+// Call Unsubscribe with the returned id when the client disconnects.
+func (b *Broker) Subscribe(lastEventID int64) (id int, ch <-chan DashboardSnapshot, replay []DashboardSnapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextSubID++
+	id = b.nextSubID
+	subCh := make(chan DashboardSnapshot, subscriberBufferSize)
+	b.subscribers[id] = subCh
+
+	for _, s := range b.replay {
+		if s.ID > lastEventID {
+			replay = append(replay, s)
+		}
+	}
+
+	return id, subCh, replay
+}
+
+// Unsubscribe removes and closes a subscriber's channel.
+func (b *Broker) Unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.subscribers[id]; ok {
+		close(ch)
+		delete(b.subscribers, id)
+	}
+}
+
+// Shutdown closes every subscriber channel so HTTP handlers blocked reading
+// from them can return. Call before DashboardService.WaitBackground during
+// graceful shutdown.
+func (b *Broker) Shutdown() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for id, ch := range b.subscribers {
+		close(ch)
+		delete(b.subscribers, id)
+	}
+}
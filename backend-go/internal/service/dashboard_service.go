@@ -2,49 +2,63 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"sync"
 	"time"
 
+	"github.com/smartcity/backend/internal/alerts"
 	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/metrics"
 )
 
 // DashboardService aggregates all live data
 type DashboardService struct {
-	weatherSvc *WeatherService
-	trafficSvc *TrafficService
-	repo       DataRepository
+	weatherSvc  *WeatherService
+	trafficSvc  *TrafficService
+	transitSvc  *TransitService
+	repo        DataRepository
+	alertEngine *alerts.Engine
 
 	wgBg sync.WaitGroup // tracks background goroutines for graceful shutdown
 }
 
-// NewDashboardService creates a new dashboard service
+// NewDashboardService creates a new dashboard service. alertEngine evaluates
+// every GetDashboardData snapshot against the configured rules; pass an
+// engine with no rules (alerts.NewEngine(nil)) to disable alerting.
+// transitSvc may be nil, in which case dashboard snapshots carry no vehicles.
 func NewDashboardService(
 	weatherSvc *WeatherService,
 	trafficSvc *TrafficService,
+	transitSvc *TransitService,
 	repo DataRepository,
+	alertEngine *alerts.Engine,
 ) *DashboardService {
 	return &DashboardService{
-		weatherSvc: weatherSvc,
-		trafficSvc: trafficSvc,
-		repo:       repo,
+		weatherSvc:  weatherSvc,
+		trafficSvc:  trafficSvc,
+		transitSvc:  transitSvc,
+		repo:        repo,
+		alertEngine: alertEngine,
 	}
 }
 
-// WaitBackground blocks until all background save goroutines complete.
-// Call during graceful shutdown to avoid dropped writes.
+// WaitBackground blocks until all background save and alert-dispatch
+// goroutines complete. Call during graceful shutdown to avoid dropped writes.
 func (s *DashboardService) WaitBackground() {
 	s.wgBg.Wait()
+	s.alertEngine.WaitBackground()
 }
 
 // GetDashboardData fetches all live data concurrently using goroutines
 func (s *DashboardService) GetDashboardData(ctx context.Context) (domain.DashboardData, error) {
 	var (
-		weather domain.Weather
-		traffic domain.Traffic
-		wg      sync.WaitGroup
-		mu      sync.Mutex
-		errs    []error
+		weather  domain.Weather
+		traffic  domain.Traffic
+		vehicles []domain.Vehicle
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
 	)
 
 	// Fetch weather concurrently
@@ -75,6 +89,22 @@ func (s *DashboardService) GetDashboardData(ctx context.Context) (domain.Dashboa
 		mu.Unlock()
 	}()
 
+	// Fetch transit vehicle positions concurrently, if configured
+	if s.transitSvc != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := s.transitSvc.GetVehicles(ctx)
+			mu.Lock()
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				vehicles = v
+			}
+			mu.Unlock()
+		}()
+	}
+
 	wg.Wait()
 
 	// Log any errors that occurred
@@ -82,6 +112,15 @@ func (s *DashboardService) GetDashboardData(ctx context.Context) (domain.Dashboa
 		log.Printf("Dashboard data fetch error: %v", err)
 	}
 
+	metrics.TrafficCongestionIndex.Set(traffic.CongestionIndex)
+	incidentsByType := make(map[string]int)
+	for _, inc := range traffic.Incidents {
+		incidentsByType[inc.Type]++
+	}
+	for incType, count := range incidentsByType {
+		metrics.TrafficIncidentCount.WithLabelValues(incType).Set(float64(count))
+	}
+
 	// Persist data to database asynchronously (tracked for graceful shutdown)
 	s.wgBg.Add(1)
 	go func() {
@@ -100,12 +139,19 @@ func (s *DashboardService) GetDashboardData(ctx context.Context) (domain.Dashboa
 		}
 	}()
 
-	// Even with errors, return what we have
-	return domain.DashboardData{
+	dashboardData := domain.DashboardData{
 		Weather:   weather,
 		Traffic:   traffic,
+		Vehicles:  vehicles,
 		Timestamp: time.Now(),
-	}, nil
+	}
+
+	// Evaluate alert rules against the fresh snapshot; sink dispatch happens
+	// in the background inside Evaluate, so this never blocks the caller.
+	s.alertEngine.Evaluate(dashboardData)
+
+	// Even with errors, return what we have
+	return dashboardData, nil
 }
 
 // GetWeather returns current weather
@@ -113,7 +159,57 @@ func (s *DashboardService) GetWeather(ctx context.Context) (domain.Weather, erro
 	return s.weatherSvc.GetCurrentWeather(ctx)
 }
 
+// GetWeatherFromProvider returns current weather from a single named
+// provider, bypassing the configured fallback chain. Used by the
+// `?provider=` override on GET /api/v1/weather.
+func (s *DashboardService) GetWeatherFromProvider(ctx context.Context, name string) (domain.Weather, error) {
+	return s.weatherSvc.GetCurrentWeatherFromProvider(ctx, name)
+}
+
 // GetTraffic returns current traffic
 func (s *DashboardService) GetTraffic(ctx context.Context) (domain.Traffic, error) {
 	return s.trafficSvc.GetCurrentTraffic(ctx)
 }
+
+// WeatherProviderHealth returns the last known health of each configured
+// weather provider, for inclusion in GET /health.
+func (s *DashboardService) WeatherProviderHealth() map[string]ProviderHealth {
+	return s.weatherSvc.ProviderHealth()
+}
+
+// GetForecast returns hourly/daily weather forecasts for the next `hours`
+// hours and `days` days.
+func (s *DashboardService) GetForecast(ctx context.Context, hours, days int) (domain.WeatherForecast, error) {
+	return s.weatherSvc.GetForecast(ctx, hours, days)
+}
+
+// GetTransitVehicles returns the live transit vehicle positions, or an empty
+// slice if no TransitService is configured.
+func (s *DashboardService) GetTransitVehicles(ctx context.Context) ([]domain.Vehicle, error) {
+	if s.transitSvc == nil {
+		return nil, nil
+	}
+	return s.transitSvc.GetVehicles(ctx)
+}
+
+// GetTransitAlerts returns the active transit service alerts, or an empty
+// slice if no TransitService is configured.
+func (s *DashboardService) GetTransitAlerts(ctx context.Context) ([]domain.TransitAlert, error) {
+	if s.transitSvc == nil {
+		return nil, nil
+	}
+	return s.transitSvc.GetAlerts(ctx)
+}
+
+// GetTransitTripUpdate returns the predicted stop-level delays for tripID.
+func (s *DashboardService) GetTransitTripUpdate(ctx context.Context, tripID string) (domain.TripUpdate, error) {
+	if s.transitSvc == nil {
+		return domain.TripUpdate{}, fmt.Errorf("dashboard_service: transit is not configured")
+	}
+	return s.transitSvc.GetTripUpdate(ctx, tripID)
+}
+
+// Alerts returns the most recently fired/resolved alerts, for GET /api/v1/alerts.
+func (s *DashboardService) Alerts() []alerts.Alert {
+	return s.alertEngine.History()
+}
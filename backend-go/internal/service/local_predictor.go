@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// localPredictorFeatureCount is the length of the regression input vector:
+// bias, sin/cos of day-of-year, sin/cos of hour-of-day, and the anchoring
+// AQI/traffic/temperature reading (see localFeatureVector).
+const localPredictorFeatureCount = 8
+
+// minTrainingSamples is the fewest (feature, target) pairs Fit will accept;
+// below this a fit is refused rather than producing an overfit model off a
+// handful of points.
+const minTrainingSamples = 10
+
+// matchTolerance bounds how far apart a weather and traffic reading's
+// timestamps may be and still be treated as the "same" observation when
+// building training pairs.
+const matchTolerance = 30 * time.Minute
+
+// ridgeLambda is a small L2 penalty added to the normal equations' diagonal
+// so fitting stays numerically stable even when features are collinear
+// (e.g. AQI barely varies within a single season).
+const ridgeLambda = 1e-3
+
+// regressionWeights holds the fitted linear-regression coefficients for both
+// targets LocalPredictor predicts, plus metadata surfaced to operators.
+type regressionWeights struct {
+	AQI      []float64 `json:"aqi_weights"`
+	Traffic  []float64 `json:"traffic_weights"`
+	FittedAt time.Time `json:"fitted_at"`
+	Samples  int       `json:"samples"`
+}
+
+// LocalPredictor is a pure-Go fallback Predictor used when the Python ML
+// service (MLBridge) is unreachable. It fits two closed-form linear
+// regressions — one for AQI, one for traffic congestion index — against
+// recent history and persists the fitted weights to disk so a restart
+// doesn't start untrained.
+type LocalPredictor struct {
+	path string
+
+	mu      sync.RWMutex
+	weights regressionWeights
+}
+
+// NewLocalPredictor creates a LocalPredictor, loading previously persisted
+// weights from path if present. A missing/corrupt file just leaves the model
+// untrained until the first fit completes, rather than failing to start.
+func NewLocalPredictor(path string) *LocalPredictor {
+	p := &LocalPredictor{path: path}
+	if data, err := os.ReadFile(path); err == nil {
+		var w regressionWeights
+		if err := json.Unmarshal(data, &w); err == nil {
+			p.weights = w
+		}
+	}
+	return p
+}
+
+func (p *LocalPredictor) Name() string { return "local-regression" }
+
+// Predict estimates AQI and traffic index from the model's current weights,
+// anchored on the request's live readings and the request date's
+// seasonality. It returns an error (not a guess) if the model hasn't been
+// trained yet, so CompositePredictor can fall through to the next predictor
+// instead of serving an all-zero-weight prediction.
+func (p *LocalPredictor) Predict(_ context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error) {
+	p.mu.RLock()
+	w := p.weights
+	p.mu.RUnlock()
+
+	if len(w.AQI) == 0 || len(w.Traffic) == 0 {
+		return domain.PredictionResponse{}, fmt.Errorf("local_predictor: model not yet trained")
+	}
+
+	target := time.Now()
+	if req.Date != "" {
+		if t, err := time.Parse("2006-01-02", req.Date); err == nil {
+			target = t
+		}
+	}
+
+	x := localFeatureVector(target, intPtrOr(req.LiveAQI, 0), floatPtrOr(req.LiveTraffic, 0), floatPtrOr(req.LiveTemp, 0))
+	aqi := dotProduct(w.AQI, x)
+	traffic := dotProduct(w.Traffic, x)
+
+	return domain.PredictionResponse{
+		Prediction: fmt.Sprintf(
+			"Local fallback estimate (Python ML service unavailable): AQI around %d, traffic index around %.0f, from a seasonal regression trained on %d historical samples.",
+			int(math.Round(aqi)), traffic, w.Samples,
+		),
+		ConfidenceScore: 0.5,
+		AQIPrediction:   int(math.Round(aqi)),
+		TrafficIndex:    math.Round(traffic*10) / 10,
+		Reasoning:       "Go fallback linear regression over recent weather/traffic history",
+		IsMock:          false,
+		Degraded:        true,
+	}, nil
+}
+
+// localFeatureVector builds the regression input: a bias term, sin/cos
+// encodings of day-of-year and hour-of-day (so Jan 1 sits close to Dec 31,
+// and 23:00 close to 00:00), and the anchoring AQI/traffic/temperature
+// reading.
+func localFeatureVector(t time.Time, aqi, traffic, temp float64) []float64 {
+	dayAngle := 2 * math.Pi * float64(t.YearDay()) / 365
+	hourAngle := 2 * math.Pi * float64(t.Hour()) / 24
+	return []float64{
+		1,
+		math.Sin(dayAngle), math.Cos(dayAngle),
+		math.Sin(hourAngle), math.Cos(hourAngle),
+		aqi, traffic, temp,
+	}
+}
+
+func dotProduct(w, x []float64) float64 {
+	var sum float64
+	for i := range w {
+		sum += w[i] * x[i]
+	}
+	return sum
+}
+
+func intPtrOr(v *int, fallback float64) float64 {
+	if v == nil {
+		return fallback
+	}
+	return float64(*v)
+}
+
+func floatPtrOr(v *float64, fallback float64) float64 {
+	if v == nil {
+		return fallback
+	}
+	return *v
+}
+
+// trainingPair is one (features, targets) row built from two chronologically
+// adjacent historical readings: prev's AQI/traffic/temperature anchor the
+// input, and cur's timestamp supplies the seasonality features — exactly
+// mirroring what Predict does with "live" values and a target date.
+type trainingPair struct {
+	x             []float64
+	targetAQI     float64
+	targetTraffic float64
+}
+
+// combinedReading pairs a weather and traffic reading taken at (about) the
+// same time, since the two histories are recorded independently.
+type combinedReading struct {
+	timestamp time.Time
+	aqi       float64
+	traffic   float64
+	temp      float64
+}
+
+// Fit re-trains both regressions from repo's recent history and persists the
+// result. Called once at startup and periodically by RunBackgroundTrainer.
+func (p *LocalPredictor) Fit(ctx context.Context, repo domain.DataRepository, lookback time.Duration) error {
+	to := time.Now()
+	from := to.Add(-lookback)
+
+	weatherHist, err := repo.GetHistoricalWeather(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("local_predictor: fetch weather history: %w", err)
+	}
+	trafficHist, err := repo.GetHistoricalTraffic(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("local_predictor: fetch traffic history: %w", err)
+	}
+
+	pairs := buildTrainingPairs(weatherHist, trafficHist)
+	if len(pairs) < minTrainingSamples {
+		return fmt.Errorf("local_predictor: only %d training samples (need at least %d)", len(pairs), minTrainingSamples)
+	}
+
+	aqiWeights, err := fitLinearRegression(pairs, func(tp trainingPair) float64 { return tp.targetAQI })
+	if err != nil {
+		return fmt.Errorf("local_predictor: fit AQI model: %w", err)
+	}
+	trafficWeights, err := fitLinearRegression(pairs, func(tp trainingPair) float64 { return tp.targetTraffic })
+	if err != nil {
+		return fmt.Errorf("local_predictor: fit traffic model: %w", err)
+	}
+
+	w := regressionWeights{AQI: aqiWeights, Traffic: trafficWeights, FittedAt: time.Now(), Samples: len(pairs)}
+	p.mu.Lock()
+	p.weights = w
+	p.mu.Unlock()
+
+	return p.persist(w)
+}
+
+func (p *LocalPredictor) persist(w regressionWeights) error {
+	if p.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("local_predictor: marshal weights: %w", err)
+	}
+	if err := os.WriteFile(p.path, data, 0o644); err != nil {
+		return fmt.Errorf("local_predictor: persist weights to %s: %w", p.path, err)
+	}
+	return nil
+}
+
+// RunBackgroundTrainer fits once immediately, then periodically re-fits so
+// the model stays current as new history accumulates. A failed fit (e.g. too
+// little history overnight) is logged, not fatal — the previous weights, if
+// any, keep serving. Blocks until ctx is cancelled; run it in its own
+// goroutine.
+func (p *LocalPredictor) RunBackgroundTrainer(ctx context.Context, repo domain.DataRepository, interval, lookback time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	if lookback <= 0 {
+		lookback = 30 * 24 * time.Hour
+	}
+
+	if err := p.Fit(ctx, repo, lookback); err != nil {
+		log.Printf("local_predictor: initial fit failed, will retry: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Fit(ctx, repo, lookback); err != nil {
+				log.Printf("local_predictor: periodic fit failed: %v", err)
+				continue
+			}
+			log.Printf("local_predictor: refit complete")
+		}
+	}
+}
+
+// buildTrainingPairs matches weather and traffic readings by nearest
+// timestamp, then turns each chronologically adjacent pair of matched
+// readings into one trainingPair.
+func buildTrainingPairs(weatherHist []domain.Weather, trafficHist []domain.Traffic) []trainingPair {
+	if len(weatherHist) == 0 || len(trafficHist) == 0 {
+		return nil
+	}
+
+	sort.Slice(weatherHist, func(i, j int) bool { return weatherHist[i].Timestamp.Before(weatherHist[j].Timestamp) })
+	sort.Slice(trafficHist, func(i, j int) bool { return trafficHist[i].Timestamp.Before(trafficHist[j].Timestamp) })
+
+	var combined []combinedReading
+	j := 0
+	for _, w := range weatherHist {
+		for j < len(trafficHist)-1 && trafficHist[j+1].Timestamp.Before(w.Timestamp) {
+			j++
+		}
+		t := trafficHist[j]
+		if j+1 < len(trafficHist) {
+			// trafficHist[j+1] is at or after w; take it if it is closer.
+			before := w.Timestamp.Sub(t.Timestamp)
+			if before < 0 {
+				before = -before
+			}
+			if trafficHist[j+1].Timestamp.Sub(w.Timestamp) < before {
+				t = trafficHist[j+1]
+			}
+		}
+		if diff := w.Timestamp.Sub(t.Timestamp); diff > matchTolerance || diff < -matchTolerance {
+			continue
+		}
+		combined = append(combined, combinedReading{
+			timestamp: w.Timestamp,
+			aqi:       float64(w.AQI),
+			traffic:   t.CongestionIndex,
+			temp:      w.Temperature,
+		})
+	}
+
+	var pairs []trainingPair
+	for i := 1; i < len(combined); i++ {
+		prev, cur := combined[i-1], combined[i]
+		pairs = append(pairs, trainingPair{
+			x:             localFeatureVector(cur.timestamp, prev.aqi, prev.traffic, prev.temp),
+			targetAQI:     cur.aqi,
+			targetTraffic: cur.traffic,
+		})
+	}
+	return pairs
+}
+
+// fitLinearRegression solves ridge-regularized least squares
+// (X^T X + λI) w = X^T y in closed form via Gaussian elimination — with only
+// localPredictorFeatureCount features and at most a few thousand historical
+// samples, this is simpler and fast enough without a linear-algebra
+// dependency.
+func fitLinearRegression(pairs []trainingPair, target func(trainingPair) float64) ([]float64, error) {
+	n := localPredictorFeatureCount
+	xtx := make([][]float64, n)
+	for i := range xtx {
+		xtx[i] = make([]float64, n)
+	}
+	xty := make([]float64, n)
+
+	for _, tp := range pairs {
+		y := target(tp)
+		for i := 0; i < n; i++ {
+			xty[i] += tp.x[i] * y
+			for k := 0; k < n; k++ {
+				xtx[i][k] += tp.x[i] * tp.x[k]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		xtx[i][i] += ridgeLambda
+	}
+
+	return solveLinearSystem(xtx, xty)
+}
+
+// solveLinearSystem solves Ax = b via Gaussian elimination with partial
+// pivoting, mutating a and b in place.
+func solveLinearSystem(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, fmt.Errorf("local_predictor: singular matrix fitting regression")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, nil
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/httpcache"
+	"github.com/smartcity/backend/internal/metrics"
 )
 
 // MLBridge handles communication with Python ML service
@@ -17,18 +19,35 @@ type MLBridge struct {
 	httpClient *http.Client
 }
 
-// NewMLBridge creates a new ML bridge
-func NewMLBridge(serviceURL string) *MLBridge {
+// NewMLBridge creates a new ML bridge. cacheStore backs conditional-request
+// caching for GET calls (e.g. GetStats); the Predict POST always passes
+// through untouched since CachingTransport only caches GET.
+func NewMLBridge(serviceURL string, cacheStore httpcache.Store) *MLBridge {
 	return &MLBridge{
 		serviceURL: serviceURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: httpcache.NewCachingTransport(cacheStore),
 		},
 	}
 }
 
-// Predict calls the Python ML service for predictions
+// Name identifies this Predictor implementation.
+func (b *MLBridge) Name() string { return "ml-service" }
+
+// Predict calls the Python ML service for predictions. Unlike the old
+// behavior, a service outage now returns an error instead of silently
+// substituting a mock response — callers (see service.Predictor) are
+// expected to fall back to LocalPredictor/MockPredictor themselves so the
+// degradation is visible in PredictionResponse.Degraded.
 func (b *MLBridge) Predict(ctx context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error) {
+	start := time.Now()
+	prediction, err := b.predict(ctx, req)
+	metrics.ObserveProviderRequest("ml-service", err, time.Since(start))
+	return prediction, err
+}
+
+func (b *MLBridge) predict(ctx context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error) {
 	// Prepare request body
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -46,13 +65,12 @@ func (b *MLBridge) Predict(ctx context.Context, req domain.PredictionRequest) (d
 	// Execute request
 	resp, err := b.httpClient.Do(httpReq)
 	if err != nil {
-		// Return mock prediction on error
-		return b.getMockPrediction(req), nil
+		return domain.PredictionResponse{}, fmt.Errorf("ml_bridge: request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return b.getMockPrediction(req), nil
+		return domain.PredictionResponse{}, fmt.Errorf("ml_bridge: status %d", resp.StatusCode)
 	}
 
 	// Parse response
@@ -64,6 +82,33 @@ func (b *MLBridge) Predict(ctx context.Context, req domain.PredictionRequest) (d
 	return prediction, nil
 }
 
+// GetStats proxies to the Python ML service's /stats endpoint, returning the
+// decoded JSON body as-is since its shape is defined service-side.
+func (b *MLBridge) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/stats", b.serviceURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ml_bridge: failed to create stats request: %w", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ml_bridge: stats request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ml_bridge: stats returned status %d", resp.StatusCode)
+	}
+
+	var stats map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("ml_bridge: failed to decode stats: %w", err)
+	}
+
+	return stats, nil
+}
+
 // Health checks ML service connectivity
 func (b *MLBridge) Health(ctx context.Context) error {
 	url := fmt.Sprintf("%s/health", b.serviceURL)
@@ -85,8 +130,9 @@ func (b *MLBridge) Health(ctx context.Context) error {
 	return nil
 }
 
-// getMockPrediction returns a fallback prediction
-func (b *MLBridge) getMockPrediction(req domain.PredictionRequest) domain.PredictionResponse {
+// mockPrediction returns a canned seasonal prediction, used by MockPredictor
+// as the last resort in the predictor chain.
+func mockPrediction() domain.PredictionResponse {
 	// Simple mock logic based on date
 	month := time.Now().Month()
 	var aqi int
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/metrics"
+)
+
+func init() {
+	gob.Register(domain.PredictionRequest{})
+	gob.Register(domain.PredictionResponse{})
+}
+
+const defaultPredictionCacheCapacity = 256
+
+// cacheEntry is one LRU slot. It is never gob-encoded directly — see
+// persistedPredictionEntry for the on-disk snapshot shape, since gob silently
+// drops unexported fields and cacheEntry needs to stay unexported for the
+// container/list bookkeeping below.
+type cacheEntry struct {
+	key       string
+	response  domain.PredictionResponse
+	expiresAt time.Time
+}
+
+// persistedPredictionEntry is the exported, gob-encodable mirror of a
+// cacheEntry used only for Snapshot/restore.
+type persistedPredictionEntry struct {
+	Key       string
+	Response  domain.PredictionResponse
+	ExpiresAt time.Time
+}
+
+// PredictionCache wraps a Predictor with an LRU cache keyed on a normalized
+// hash of the request, following the same container/list + map pattern as
+// httpcache.MemoryStore. Entries expire after ttl regardless of LRU
+// pressure, since a stale prediction is worse than a recomputed one.
+type PredictionCache struct {
+	next         Predictor
+	capacity     int
+	ttl          time.Duration
+	snapshotPath string
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// NewPredictionCache creates a cache in front of next. capacity <= 0 defaults
+// to 256 entries; ttl <= 0 disables expiry (entries only evicted by LRU).
+// snapshotPath, if non-empty, is read on construction (see restore) and
+// should be written via Snapshot during graceful shutdown.
+func NewPredictionCache(next Predictor, capacity int, ttl time.Duration, snapshotPath string) *PredictionCache {
+	if capacity <= 0 {
+		capacity = defaultPredictionCacheCapacity
+	}
+	c := &PredictionCache{
+		next:         next,
+		capacity:     capacity,
+		ttl:          ttl,
+		snapshotPath: snapshotPath,
+		ll:           list.New(),
+		items:        make(map[string]*list.Element),
+	}
+	if snapshotPath != "" {
+		if err := c.restore(snapshotPath); err != nil {
+			log.Printf("prediction_cache: could not restore snapshot from %s: %v", snapshotPath, err)
+		}
+	}
+	return c
+}
+
+// Name identifies this Predictor implementation.
+func (c *PredictionCache) Name() string { return "prediction-cache(" + c.next.Name() + ")" }
+
+// Predict returns a cached response when the normalized request hash is a
+// live hit, otherwise delegates to the wrapped Predictor and stores the
+// result.
+func (c *PredictionCache) Predict(ctx context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error) {
+	key := predictionCacheKey(req)
+
+	if resp, ok := c.get(key); ok {
+		metrics.PredictionCacheTotal.WithLabelValues("hit").Inc()
+		return resp, nil
+	}
+	metrics.PredictionCacheTotal.WithLabelValues("miss").Inc()
+
+	resp, err := c.next.Predict(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	c.store(key, resp)
+	return resp, nil
+}
+
+func (c *PredictionCache) get(key string) (domain.PredictionResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return domain.PredictionResponse{}, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.ll.Remove(elem)
+		delete(c.items, key)
+		return domain.PredictionResponse{}, false
+	}
+	c.ll.MoveToFront(elem)
+	return entry.response, true
+}
+
+func (c *PredictionCache) store(key string, resp domain.PredictionResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Time{}
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*cacheEntry).response = resp
+		elem.Value.(*cacheEntry).expiresAt = expiresAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := c.ll.PushFront(&cacheEntry{key: key, response: resp, expiresAt: expiresAt})
+	c.items[key] = elem
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
+
+// Invalidate clears every cached entry, forcing the next request for each
+// key to recompute via the wrapped Predictor.
+func (c *PredictionCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll = list.New()
+	c.items = make(map[string]*list.Element)
+}
+
+// Snapshot persists the current cache contents to c.snapshotPath as gob. A
+// no-op if no path was configured.
+func (c *PredictionCache) Snapshot() error {
+	if c.snapshotPath == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	entries := make([]persistedPredictionEntry, 0, c.ll.Len())
+	for elem := c.ll.Front(); elem != nil; elem = elem.Next() {
+		e := elem.Value.(*cacheEntry)
+		entries = append(entries, persistedPredictionEntry{
+			Key:       e.key,
+			Response:  e.response,
+			ExpiresAt: e.expiresAt,
+		})
+	}
+	c.mu.Unlock()
+
+	f, err := os.Create(c.snapshotPath)
+	if err != nil {
+		return fmt.Errorf("prediction_cache: failed to create snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	if err := gob.NewEncoder(f).Encode(entries); err != nil {
+		return fmt.Errorf("prediction_cache: failed to encode snapshot: %w", err)
+	}
+	return nil
+}
+
+// restore loads a previously written Snapshot, skipping entries that have
+// already expired.
+func (c *PredictionCache) restore(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("prediction_cache: failed to open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	var entries []persistedPredictionEntry
+	if err := gob.NewDecoder(f).Decode(&entries); err != nil {
+		return fmt.Errorf("prediction_cache: failed to decode snapshot: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, e := range entries {
+		if c.ttl > 0 && now.After(e.ExpiresAt) {
+			continue
+		}
+		elem := c.ll.PushBack(&cacheEntry{key: e.Key, response: e.Response, expiresAt: e.ExpiresAt})
+		c.items[e.Key] = elem
+	}
+	return nil
+}
+
+// predictionCacheKey normalizes req to a stable SHA-256 hash, rounding noisy
+// live-sensor fields so near-duplicate requests share a cache entry:
+// LiveTraffic is bucketed to the nearest 5, Temperature and
+// ForecastTempTrend to the nearest whole degree. LiveTemp is not part of the
+// key.
+func predictionCacheKey(req domain.PredictionRequest) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "date=%s|temp=%s|query=%s|lang=%s|aqi=%s|traffic=%s|forecast_precip=%s|forecast_trend=%s",
+		req.Date,
+		roundedFloatPtr(req.Temperature, 1),
+		req.Query,
+		req.Language,
+		formatIntPtr(req.LiveAQI),
+		roundedFloatPtr(req.LiveTraffic, 5),
+		formatIntPtr(req.ForecastPrecipProbability),
+		roundedFloatPtr(req.ForecastTempTrend, 1),
+	)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func roundedFloatPtr(v *float64, bucket float64) string {
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%.0f", math.Round(*v/bucket)*bucket)
+}
+
+func formatIntPtr(v *int) string {
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%d", *v)
+}
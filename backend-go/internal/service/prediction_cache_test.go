@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// countingPredictor returns a canned response and counts how many times
+// Predict was actually called through to it (i.e. cache misses).
+type countingPredictor struct {
+	calls int
+	resp  domain.PredictionResponse
+}
+
+func (p *countingPredictor) Name() string { return "counting" }
+
+func (p *countingPredictor) Predict(ctx context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error) {
+	p.calls++
+	return p.resp, nil
+}
+
+func TestPredictionCache_HitAvoidsRecompute(t *testing.T) {
+	next := &countingPredictor{resp: domain.PredictionResponse{Prediction: "sunny"}}
+	cache := NewPredictionCache(next, 10, time.Minute, "")
+
+	req := domain.PredictionRequest{Date: "2026-07-26", Query: "weather"}
+
+	resp1, err := cache.Predict(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Predict: %v", err)
+	}
+	resp2, err := cache.Predict(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Predict: %v", err)
+	}
+
+	if next.calls != 1 {
+		t.Errorf("next.calls = %d, want 1 (second call should hit cache)", next.calls)
+	}
+	if resp1 != resp2 {
+		t.Errorf("resp1 = %+v, resp2 = %+v, want equal", resp1, resp2)
+	}
+}
+
+func TestPredictionCache_ExpiredEntryRecomputes(t *testing.T) {
+	next := &countingPredictor{resp: domain.PredictionResponse{Prediction: "rainy"}}
+	cache := NewPredictionCache(next, 10, time.Nanosecond, "")
+
+	req := domain.PredictionRequest{Date: "2026-07-26"}
+	if _, err := cache.Predict(context.Background(), req); err != nil {
+		t.Fatalf("Predict: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if _, err := cache.Predict(context.Background(), req); err != nil {
+		t.Fatalf("Predict: %v", err)
+	}
+
+	if next.calls != 2 {
+		t.Errorf("next.calls = %d, want 2 (entry should have expired)", next.calls)
+	}
+}
+
+func TestPredictionCache_LRUEvictsOldestOverCapacity(t *testing.T) {
+	next := &countingPredictor{resp: domain.PredictionResponse{Prediction: "x"}}
+	cache := NewPredictionCache(next, 2, time.Minute, "")
+
+	reqA := domain.PredictionRequest{Query: "a"}
+	reqB := domain.PredictionRequest{Query: "b"}
+	reqC := domain.PredictionRequest{Query: "c"}
+
+	cache.Predict(context.Background(), reqA)
+	cache.Predict(context.Background(), reqB)
+	cache.Predict(context.Background(), reqC) // capacity 2: evicts reqA
+
+	next.calls = 0
+	cache.Predict(context.Background(), reqA) // should miss, was evicted
+	if next.calls != 1 {
+		t.Errorf("next.calls = %d, want 1 (reqA should have been evicted)", next.calls)
+	}
+}
+
+func TestPredictionCache_SnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prediction_cache.gob")
+
+	next := &countingPredictor{resp: domain.PredictionResponse{
+		Prediction:      "clear skies",
+		ConfidenceScore: 0.87,
+		AQIPrediction:   42,
+	}}
+	cache := NewPredictionCache(next, 10, time.Hour, path)
+	req := domain.PredictionRequest{Date: "2026-07-26", Query: "weather tomorrow"}
+
+	if _, err := cache.Predict(context.Background(), req); err != nil {
+		t.Fatalf("Predict: %v", err)
+	}
+	if err := cache.Snapshot(); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("snapshot file not written: %v", err)
+	}
+
+	restoredNext := &countingPredictor{resp: domain.PredictionResponse{Prediction: "should not be used"}}
+	restored := NewPredictionCache(restoredNext, 10, time.Hour, path)
+
+	resp, err := restored.Predict(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Predict after restore: %v", err)
+	}
+	if resp != next.resp {
+		t.Errorf("restored response = %+v, want %+v", resp, next.resp)
+	}
+	if restoredNext.calls != 0 {
+		t.Errorf("restoredNext.calls = %d, want 0 (entry should have survived restore as a cache hit)", restoredNext.calls)
+	}
+}
+
+func TestPredictionCache_SnapshotSkipsExpiredEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prediction_cache.gob")
+
+	next := &countingPredictor{resp: domain.PredictionResponse{Prediction: "stale"}}
+	cache := NewPredictionCache(next, 10, time.Nanosecond, path)
+	req := domain.PredictionRequest{Date: "2026-07-26"}
+
+	if _, err := cache.Predict(context.Background(), req); err != nil {
+		t.Fatalf("Predict: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := cache.Snapshot(); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	restoredNext := &countingPredictor{resp: domain.PredictionResponse{Prediction: "fresh"}}
+	restored := NewPredictionCache(restoredNext, 10, time.Nanosecond, path)
+
+	if _, err := restored.Predict(context.Background(), req); err != nil {
+		t.Fatalf("Predict after restore: %v", err)
+	}
+	if restoredNext.calls != 1 {
+		t.Errorf("restoredNext.calls = %d, want 1 (expired entry should not have been restored)", restoredNext.calls)
+	}
+}
+
+func TestPredictionCache_Invalidate(t *testing.T) {
+	next := &countingPredictor{resp: domain.PredictionResponse{Prediction: "x"}}
+	cache := NewPredictionCache(next, 10, time.Minute, "")
+	req := domain.PredictionRequest{Query: "a"}
+
+	cache.Predict(context.Background(), req)
+	cache.Invalidate()
+	next.calls = 0
+	cache.Predict(context.Background(), req)
+
+	if next.calls != 1 {
+		t.Errorf("next.calls = %d, want 1 (Invalidate should force a recompute)", next.calls)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// Predictor produces a PredictionResponse for a PredictionRequest. Multiple
+// implementations let Predict degrade gracefully: MLBridge talks to the
+// Python service, LocalPredictor is a pure-Go regression fallback, and
+// MockPredictor is the last-resort canned estimate — mirroring the same
+// try-in-order-with-fallback shape WeatherService uses for its provider
+// chain.
+type Predictor interface {
+	// Name identifies the predictor, e.g. "ml-service" or "local-regression".
+	Name() string
+	Predict(ctx context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error)
+}
+
+// CompositePredictor tries each configured Predictor in order, returning the
+// first successful response unchanged — including whatever Degraded/IsMock
+// flags that predictor set — and falling back to the next on error.
+type CompositePredictor struct {
+	predictors []Predictor
+}
+
+// NewCompositePredictor creates a CompositePredictor trying predictors in
+// the given order.
+func NewCompositePredictor(predictors ...Predictor) *CompositePredictor {
+	return &CompositePredictor{predictors: predictors}
+}
+
+func (c *CompositePredictor) Name() string {
+	if len(c.predictors) == 0 {
+		return "none"
+	}
+	return c.predictors[0].Name()
+}
+
+func (c *CompositePredictor) Predict(ctx context.Context, req domain.PredictionRequest) (domain.PredictionResponse, error) {
+	var lastErr error
+	for _, p := range c.predictors {
+		resp, err := p.Predict(ctx, req)
+		if err != nil {
+			log.Printf("%s predictor failed, trying next: %v", p.Name(), err)
+			lastErr = err
+			continue
+		}
+		return resp, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no predictors configured")
+	}
+	return domain.PredictionResponse{}, lastErr
+}
+
+// MockPredictor is a last-resort Predictor that never errors, returning a
+// canned seasonal estimate. Kept at the end of the chain so Predict never
+// hard-fails even when both the ML service and the local regression are
+// unavailable.
+type MockPredictor struct{}
+
+// NewMockPredictor creates a MockPredictor.
+func NewMockPredictor() *MockPredictor { return &MockPredictor{} }
+
+func (m *MockPredictor) Name() string { return "mock" }
+
+func (m *MockPredictor) Predict(_ context.Context, _ domain.PredictionRequest) (domain.PredictionResponse, error) {
+	return mockPrediction(), nil
+}
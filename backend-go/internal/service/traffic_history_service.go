@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+	applog "github.com/smartcity/backend/internal/log"
+)
+
+// TrafficHistoryService periodically snapshots TrafficService's merged
+// per-road congestion into a TrafficHistoryRepository, and answers
+// historical/typical-time-of-week queries over the accumulated data.
+type TrafficHistoryService struct {
+	repo domain.TrafficHistoryRepository
+}
+
+// NewTrafficHistoryService creates a traffic history service backed by repo.
+func NewTrafficHistoryService(repo domain.TrafficHistoryRepository) *TrafficHistoryService {
+	return &TrafficHistoryService{repo: repo}
+}
+
+// Run polls trafficSvc.GetCurrentTraffic every interval and persists the
+// result, until ctx is cancelled. cmd/smartcity's runner starts it as a
+// background goroutine, like Broker.Run.
+func (s *TrafficHistoryService) Run(ctx context.Context, trafficSvc *TrafficService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			traffic, err := trafficSvc.GetCurrentTraffic(ctx)
+			if err != nil {
+				applog.Default().Warnf(ctx, "traffic history: could not fetch current traffic: %v", err)
+				continue
+			}
+			if err := s.repo.SaveSnapshot(ctx, traffic); err != nil {
+				applog.Default().Warnf(ctx, "traffic history: could not save snapshot: %v", err)
+			}
+		}
+	}
+}
+
+// History returns bucketed congestion/speed averages between from and to,
+// optionally filtered to a single road name.
+func (s *TrafficHistoryService) History(ctx context.Context, from, to time.Time, road string, bucket time.Duration) ([]domain.TrafficHistoryBucket, error) {
+	return s.repo.History(ctx, from, to, road, bucket)
+}
+
+// Typical returns the long-run mean+stddev congestion recorded for the given
+// day-of-week and hour-of-day.
+func (s *TrafficHistoryService) Typical(ctx context.Context, dayOfWeek time.Weekday, hour int) (domain.TrafficTypical, error) {
+	return s.repo.Typical(ctx, dayOfWeek, hour)
+}
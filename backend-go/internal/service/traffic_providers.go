@@ -0,0 +1,612 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/text/language"
+
+	"github.com/smartcity/backend/internal/domain"
+	applog "github.com/smartcity/backend/internal/log"
+)
+
+// FlowPoint is one road TrafficService asks every configured TrafficProvider
+// to report current flow for. QueryLat/QueryLon is the single coordinate
+// passed to point-sampling APIs (e.g. TomTom's flowSegmentData); StartLat/Lon
+// and EndLat/Lon describe the road's endpoints, used to fall back to a
+// straight-line path when a provider doesn't return segment geometry.
+type FlowPoint struct {
+	RoadName           string
+	QueryLat, QueryLon float64
+	StartLat, StartLon float64
+	EndLat, EndLon     float64
+}
+
+// FlowSample is a single provider's flow reading for a FlowPoint. Path is the
+// provider's own segment geometry as [[lon,lat], ...] GeoJSON coordinates;
+// it's left nil when the provider's response didn't include geometry, which
+// TrafficService treats as a request to interpolate a straight line instead.
+type FlowSample struct {
+	RoadName      string
+	CurrentSpeed  float64 // km/h
+	FreeFlowSpeed float64 // km/h
+	Path          [][2]float64
+}
+
+// TrafficBBox is a lat/lon bounding box used for incident queries.
+type TrafficBBox struct {
+	MinLat, MinLon, MaxLat, MaxLon float64
+}
+
+// TrafficProvider fetches flow and incident data from a single upstream
+// traffic source. TrafficService queries every configured provider and
+// merges their FlowSamples (weighted by Confidence) and Incidents (deduped),
+// so — unlike WeatherProvider's first-success fallback chain — a provider
+// failing here just means it doesn't contribute to the merge, not that the
+// whole request fails, as long as at least one provider succeeds.
+//
+// TomTom, HERE, and Yandex all live in this one file rather than separate
+// service/providers/{tomtom,here,yandex} subpackages: they're small enough
+// (a few hundred lines combined) and share enough helpers (FlowPoint,
+// FlowSample, TrafficBBox above) that splitting them into subpackages would
+// mean exporting those shared types instead of just sharing the package
+// scope, for no real separation benefit — the same flat layout
+// WeatherProvider's implementations already use in weather_providers.go.
+type TrafficProvider interface {
+	// Name identifies the provider, e.g. "tomtom", used in domain.Traffic.Source
+	// and in the "contributing providers" log line.
+	Name() string
+	// Confidence weights this provider's FlowSamples in the per-road weighted
+	// average merge; higher means more trusted. Callers don't need it to sum
+	// to 1 across providers — it's a relative weight, not a probability.
+	Confidence() float64
+	// FetchFlow returns whatever flow samples this provider could read for
+	// points; points it couldn't read are simply absent, not an error.
+	// FetchFlow only returns an error when it got no samples at all.
+	FetchFlow(ctx context.Context, points []FlowPoint) ([]FlowSample, error)
+	// FetchIncidents returns active incidents within bbox.
+	FetchIncidents(ctx context.Context, bbox TrafficBBox) ([]domain.Incident, error)
+}
+
+// ---------------------------------------------------------------------------
+// TomTom
+// ---------------------------------------------------------------------------
+
+// TomTomTrafficProvider is this project's original traffic source: TomTom's
+// Traffic Flow v4 + Incidents v5 APIs (2,500 requests/day on the free tier).
+type TomTomTrafficProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewTomTomTrafficProvider creates a TomTom traffic provider.
+func NewTomTomTrafficProvider(apiKey string, httpClient *http.Client) *TomTomTrafficProvider {
+	return &TomTomTrafficProvider{apiKey: apiKey, httpClient: httpClient}
+}
+
+func (p *TomTomTrafficProvider) Name() string { return "tomtom" }
+
+// Confidence is the highest of the three providers: TomTom is the project's
+// longest-running traffic source and the one its congestion-index tuning
+// (see minFreeFlowSpeedKmh) was calibrated against.
+func (p *TomTomTrafficProvider) Confidence() float64 { return 0.9 }
+
+// tomTomFlowResponse is the TomTom Traffic Flow v4 response shape.
+type tomTomFlowResponse struct {
+	FlowSegmentData struct {
+		CurrentSpeed  float64 `json:"currentSpeed"`
+		FreeFlowSpeed float64 `json:"freeFlowSpeed"`
+		Confidence    float64 `json:"confidence"`
+		RoadClosure   bool    `json:"roadClosure"`
+		Coordinates   struct {
+			Coordinate []struct {
+				Latitude  float64 `json:"latitude"`
+				Longitude float64 `json:"longitude"`
+			} `json:"coordinate"`
+		} `json:"coordinates"`
+	} `json:"flowSegmentData"`
+}
+
+type tomTomIncidentResponse struct {
+	Incidents []tomTomIncident `json:"incidents"`
+}
+
+type tomTomIncident struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		ID               string `json:"id"`
+		IconCategory     int    `json:"iconCategory"`
+		MagnitudeOfDelay int    `json:"magnitudeOfDelay"`
+		From             string `json:"from,omitempty"`
+		To               string `json:"to,omitempty"`
+		Delay            int    `json:"delay"`
+		Events           []struct {
+			Description string `json:"description"`
+			Code        int    `json:"code"`
+		} `json:"events"`
+	} `json:"properties"`
+}
+
+// FetchFlow queries TomTom's Traffic Flow API once per point, skipping (and
+// logging) any point whose query fails rather than failing the whole batch.
+func (p *TomTomTrafficProvider) FetchFlow(ctx context.Context, points []FlowPoint) ([]FlowSample, error) {
+	samples := make([]FlowSample, 0, len(points))
+	for _, pt := range points {
+		flow, err := p.queryFlowSegment(ctx, pt.QueryLat, pt.QueryLon)
+		if err != nil {
+			applog.Default().Warnf(ctx, "tomtom: flow query failed for %s: %v", pt.RoadName, err)
+			continue
+		}
+
+		var path [][2]float64
+		for _, coord := range flow.FlowSegmentData.Coordinates.Coordinate {
+			path = append(path, [2]float64{coord.Longitude, coord.Latitude})
+		}
+
+		samples = append(samples, FlowSample{
+			RoadName:      pt.RoadName,
+			CurrentSpeed:  flow.FlowSegmentData.CurrentSpeed,
+			FreeFlowSpeed: flow.FlowSegmentData.FreeFlowSpeed,
+			Path:          path,
+		})
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("tomtom: all flow queries failed")
+	}
+	return samples, nil
+}
+
+// queryFlowSegment queries TomTom Traffic Flow for a single road point.
+func (p *TomTomTrafficProvider) queryFlowSegment(ctx context.Context, lat, lon float64) (*tomTomFlowResponse, error) {
+	url := fmt.Sprintf(
+		"https://api.tomtom.com/traffic/services/4/flowSegmentData/absolute/10/json?point=%f,%f&key=%s&unit=KMPH&thickness=1",
+		lat, lon, p.apiKey,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TomTom Flow API returned status %d", resp.StatusCode)
+	}
+
+	var flowResp tomTomFlowResponse
+	if err := json.NewDecoder(resp.Body).Decode(&flowResp); err != nil {
+		return nil, fmt.Errorf("failed to decode TomTom flow response: %w", err)
+	}
+
+	return &flowResp, nil
+}
+
+// tomTomIncidentLanguages are the TomTom `language` codes fetched for every
+// incident, matching Almaty's tri-lingual population. TomTom only returns
+// one language per call, so each is a separate request, issued in parallel.
+var tomTomIncidentLanguages = []struct {
+	tag        language.Tag
+	tomtomCode string
+}{
+	{language.Russian, "ru-RU"},
+	{language.English, "en-GB"},
+	{language.Kazakh, "kk-KZ"},
+}
+
+// FetchIncidents queries TomTom Traffic Incidents API v5 once per language in
+// tomTomIncidentLanguages, then merges the results into one incident list
+// (using the Russian response for geometry/category, since that's the
+// project's long-standing default) with a per-language Descriptions map
+// keyed by the matching incident ID.
+func (p *TomTomTrafficProvider) FetchIncidents(ctx context.Context, bbox TrafficBBox) ([]domain.Incident, error) {
+	type langResult struct {
+		tag  language.Tag
+		resp *tomTomIncidentResponse
+	}
+
+	results := make([]langResult, len(tomTomIncidentLanguages))
+	var wg sync.WaitGroup
+	for i, lang := range tomTomIncidentLanguages {
+		wg.Add(1)
+		go func(i int, tag language.Tag, tomtomCode string) {
+			defer wg.Done()
+			resp, err := p.queryIncidents(ctx, bbox, tomtomCode)
+			if err != nil {
+				applog.Default().Warnf(ctx, "tomtom: incidents query failed for %s: %v", tomtomCode, err)
+				return
+			}
+			results[i] = langResult{tag: tag, resp: resp}
+		}(i, lang.tag, lang.tomtomCode)
+	}
+	wg.Wait()
+
+	descriptionsByID := make(map[string]map[language.Tag]string)
+	var primary *tomTomIncidentResponse
+	for _, r := range results {
+		if r.resp == nil {
+			continue
+		}
+		if r.tag == language.Russian {
+			primary = r.resp
+		}
+		for _, inc := range r.resp.Incidents {
+			if descriptionsByID[inc.Properties.ID] == nil {
+				descriptionsByID[inc.Properties.ID] = make(map[language.Tag]string)
+			}
+			descriptionsByID[inc.Properties.ID][r.tag] = p.buildIncidentDescription(inc)
+		}
+	}
+
+	if primary == nil {
+		return nil, fmt.Errorf("tomtom: Russian (primary) incident query failed")
+	}
+
+	incidents := make([]domain.Incident, 0, len(primary.Incidents))
+	for _, inc := range primary.Incidents {
+		lat, lon := p.extractIncidentPosition(inc)
+		if lat == 0 && lon == 0 {
+			continue
+		}
+
+		descriptions := descriptionsByID[inc.Properties.ID]
+		incidents = append(incidents, domain.Incident{
+			Latitude:     lat,
+			Longitude:    lon,
+			Type:         p.mapTomTomCategory(inc.Properties.IconCategory),
+			Description:  descriptions[language.Russian],
+			Descriptions: descriptions,
+		})
+	}
+
+	return incidents, nil
+}
+
+// queryIncidents fetches the TomTom Incidents API for a single language code.
+func (p *TomTomTrafficProvider) queryIncidents(ctx context.Context, bbox TrafficBBox, languageCode string) (*tomTomIncidentResponse, error) {
+	url := fmt.Sprintf(
+		"https://api.tomtom.com/traffic/services/5/incidentDetails?key=%s&bbox=%f,%f,%f,%f&language=%s&categoryFilter=1,6,7,8,9,14&timeValidityFilter=present",
+		p.apiKey, bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, languageCode,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create incidents request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("TomTom Incidents API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TomTom Incidents API returned status %d", resp.StatusCode)
+	}
+
+	var incResp tomTomIncidentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&incResp); err != nil {
+		return nil, fmt.Errorf("failed to decode incidents response: %w", err)
+	}
+
+	return &incResp, nil
+}
+
+// extractIncidentPosition gets the first coordinate from incident geometry.
+func (p *TomTomTrafficProvider) extractIncidentPosition(inc tomTomIncident) (float64, float64) {
+	// TomTom uses [lon, lat] order in GeoJSON
+	if inc.Geometry.Type == "Point" {
+		var coords [2]float64
+		if err := json.Unmarshal(inc.Geometry.Coordinates, &coords); err == nil {
+			return coords[1], coords[0]
+		}
+	} else if inc.Geometry.Type == "LineString" {
+		var coords [][2]float64
+		if err := json.Unmarshal(inc.Geometry.Coordinates, &coords); err == nil && len(coords) > 0 {
+			return coords[0][1], coords[0][0]
+		}
+	}
+	return 0, 0
+}
+
+// mapTomTomCategory converts TomTom iconCategory to our incident type.
+func (p *TomTomTrafficProvider) mapTomTomCategory(category int) string {
+	switch category {
+	case 1, 14: // Accident, Broken Down Vehicle
+		return "accident"
+	case 9, 7, 8: // Road Works, Lane Closed, Road Closed
+		return "roadwork"
+	default: // Jam, other hazards
+		return "police"
+	}
+}
+
+// buildIncidentDescription creates a human-readable description.
+func (p *TomTomTrafficProvider) buildIncidentDescription(inc tomTomIncident) string {
+	desc := ""
+	if len(inc.Properties.Events) > 0 {
+		desc = inc.Properties.Events[0].Description
+	}
+	if inc.Properties.From != "" {
+		if desc != "" {
+			desc += " — "
+		}
+		desc += inc.Properties.From
+		if inc.Properties.To != "" {
+			desc += " → " + inc.Properties.To
+		}
+	}
+	if desc == "" {
+		desc = p.mapTomTomCategory(inc.Properties.IconCategory)
+	}
+	return desc
+}
+
+// ---------------------------------------------------------------------------
+// HERE
+// ---------------------------------------------------------------------------
+
+// hereFlowRadiusMeters bounds the circle HERE searches around each query
+// point for a flow-carrying road link.
+const hereFlowRadiusMeters = 200
+
+// HereTrafficProvider fetches flow and incidents from HERE Traffic API v7.
+type HereTrafficProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHereTrafficProvider creates a HERE traffic provider.
+func NewHereTrafficProvider(apiKey string, httpClient *http.Client) *HereTrafficProvider {
+	return &HereTrafficProvider{apiKey: apiKey, httpClient: httpClient}
+}
+
+func (p *HereTrafficProvider) Name() string { return "here" }
+
+// Confidence sits below TomTom (0.9): HERE Traffic v7 is a solid secondary
+// read on Almaty but doesn't have TomTom's track record in this project.
+func (p *HereTrafficProvider) Confidence() float64 { return 0.75 }
+
+type hereFlowResponse struct {
+	Results []struct {
+		Location struct {
+			Shape struct {
+				Links []struct {
+					Points []struct {
+						Lat float64 `json:"lat"`
+						Lng float64 `json:"lng"`
+					} `json:"points"`
+				} `json:"links"`
+			} `json:"shape"`
+		} `json:"location"`
+		CurrentFlow struct {
+			Speed    float64 `json:"speed"`
+			FreeFlow float64 `json:"freeFlow"`
+		} `json:"currentFlow"`
+	} `json:"results"`
+}
+
+// FetchFlow queries HERE's flow-by-circle endpoint once per point, skipping
+// (and logging) any point whose query fails or returns no results.
+func (p *HereTrafficProvider) FetchFlow(ctx context.Context, points []FlowPoint) ([]FlowSample, error) {
+	samples := make([]FlowSample, 0, len(points))
+	for _, pt := range points {
+		url := fmt.Sprintf(
+			"https://data.traffic.hereapi.com/v7/flow?locationReferencing=shape&in=circle:%f,%f;r=%d&apiKey=%s",
+			pt.QueryLat, pt.QueryLon, hereFlowRadiusMeters, p.apiKey,
+		)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("here: create request: %w", err)
+		}
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			applog.Default().Warnf(ctx, "here: flow query failed for %s: %v", pt.RoadName, err)
+			continue
+		}
+
+		var flowResp hereFlowResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&flowResp)
+		statusCode := resp.StatusCode
+		resp.Body.Close()
+		if statusCode != http.StatusOK || decodeErr != nil || len(flowResp.Results) == 0 {
+			applog.Default().Warnf(ctx, "here: no flow data for %s (status=%d)", pt.RoadName, statusCode)
+			continue
+		}
+
+		result := flowResp.Results[0]
+		var path [][2]float64
+		for _, link := range result.Location.Shape.Links {
+			for _, linkPoint := range link.Points {
+				path = append(path, [2]float64{linkPoint.Lng, linkPoint.Lat})
+			}
+		}
+
+		samples = append(samples, FlowSample{
+			RoadName:      pt.RoadName,
+			CurrentSpeed:  result.CurrentFlow.Speed,
+			FreeFlowSpeed: result.CurrentFlow.FreeFlow,
+			Path:          path,
+		})
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("here: all flow queries failed")
+	}
+	return samples, nil
+}
+
+type hereIncidentsResponse struct {
+	Results []struct {
+		Location struct {
+			Shape struct {
+				Links []struct {
+					Points []struct {
+						Lat float64 `json:"lat"`
+						Lng float64 `json:"lng"`
+					} `json:"points"`
+				} `json:"links"`
+			} `json:"shape"`
+		} `json:"location"`
+		IncidentDetails struct {
+			Type        string `json:"type"`
+			Description struct {
+				Value string `json:"value"`
+			} `json:"description"`
+		} `json:"incidentDetails"`
+	} `json:"results"`
+}
+
+// FetchIncidents queries HERE Traffic API v7's incidents-by-bbox endpoint.
+func (p *HereTrafficProvider) FetchIncidents(ctx context.Context, bbox TrafficBBox) ([]domain.Incident, error) {
+	url := fmt.Sprintf(
+		"https://data.traffic.hereapi.com/v7/incidents?in=bbox:%f,%f,%f,%f&locationReferencing=shape&apiKey=%s",
+		bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, p.apiKey,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("here: create incidents request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("here: incidents request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("here: incidents API returned status %d", resp.StatusCode)
+	}
+
+	var incResp hereIncidentsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&incResp); err != nil {
+		return nil, fmt.Errorf("here: decode incidents: %w", err)
+	}
+
+	incidents := make([]domain.Incident, 0, len(incResp.Results))
+	for _, r := range incResp.Results {
+		var lat, lon float64
+		for _, link := range r.Location.Shape.Links {
+			if len(link.Points) > 0 {
+				lat, lon = link.Points[0].Lat, link.Points[0].Lng
+				break
+			}
+		}
+		if lat == 0 && lon == 0 {
+			continue
+		}
+
+		desc := r.IncidentDetails.Description.Value
+		incidents = append(incidents, domain.Incident{
+			Latitude:     lat,
+			Longitude:    lon,
+			Type:         mapHereIncidentType(r.IncidentDetails.Type),
+			Description:  desc,
+			Descriptions: map[language.Tag]string{language.English: desc},
+		})
+	}
+
+	return incidents, nil
+}
+
+// mapHereIncidentType converts HERE's incidentDetails.type to our incident type.
+func mapHereIncidentType(hereType string) string {
+	switch hereType {
+	case "accident":
+		return "accident"
+	case "construction", "roadClosure", "laneRestriction":
+		return "roadwork"
+	default:
+		return "police"
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Yandex
+// ---------------------------------------------------------------------------
+
+// YandexTrafficProvider fetches a city-wide congestion score from Yandex's
+// public jam-level endpoint. Yandex doesn't expose a per-segment flow API the
+// way TomTom and HERE do — its traffic layer is otherwise only consumable
+// through the JS map widget — so this provider applies the single 0-10 score
+// uniformly across every requested FlowPoint instead of reading real
+// per-road speeds. That's a much rougher signal than the other two
+// providers, reflected in its lower Confidence().
+type YandexTrafficProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewYandexTrafficProvider creates a Yandex traffic provider.
+func NewYandexTrafficProvider(apiKey string, httpClient *http.Client) *YandexTrafficProvider {
+	return &YandexTrafficProvider{apiKey: apiKey, httpClient: httpClient}
+}
+
+func (p *YandexTrafficProvider) Name() string { return "yandex" }
+
+// Confidence is the lowest of the three: see the type doc comment for why.
+func (p *YandexTrafficProvider) Confidence() float64 { return 0.5 }
+
+type yandexJamsResponse struct {
+	Level float64 `json:"level"` // 0-10 city-wide congestion score
+}
+
+// FetchFlow queries Yandex's jam-level endpoint once and distributes the
+// resulting city-wide congestion ratio across every point.
+func (p *YandexTrafficProvider) FetchFlow(ctx context.Context, points []FlowPoint) ([]FlowSample, error) {
+	url := fmt.Sprintf(
+		"https://api.routing.yandex.net/v2/traffic/jams?lat=%f&lon=%f&apikey=%s",
+		domain.AlmatyCenterLat, domain.AlmatyCenterLon, p.apiKey,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("yandex: create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("yandex: jams request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("yandex: jams API returned status %d", resp.StatusCode)
+	}
+
+	var jams yandexJamsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jams); err != nil {
+		return nil, fmt.Errorf("yandex: decode jams response: %w", err)
+	}
+
+	congestionRatio := math.Max(0, math.Min(1, jams.Level/10))
+	samples := make([]FlowSample, 0, len(points))
+	for _, pt := range points {
+		samples = append(samples, FlowSample{
+			RoadName:      pt.RoadName,
+			CurrentSpeed:  minFreeFlowSpeedKmh * (1 - congestionRatio),
+			FreeFlowSpeed: minFreeFlowSpeedKmh,
+		})
+	}
+	return samples, nil
+}
+
+// FetchIncidents always returns no incidents: Yandex's public jams endpoint
+// doesn't surface discrete incidents, only the aggregate score FetchFlow uses.
+func (p *YandexTrafficProvider) FetchIncidents(ctx context.Context, bbox TrafficBBox) ([]domain.Incident, error) {
+	return nil, nil
+}
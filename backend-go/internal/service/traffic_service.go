@@ -2,81 +2,46 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
 	"math"
 	"math/rand/v2"
-	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/text/language"
+
 	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/geoutils"
+	applog "github.com/smartcity/backend/internal/log"
 )
 
-// TrafficService handles traffic data fetching from TomTom and heatmap generation
+// TrafficService merges flow and incident data from a chain of
+// TrafficProviders (TomTom, HERE, Yandex, ...) and generates a fallback
+// heatmap/road-segment simulation when none are configured or all fail.
 type TrafficService struct {
-	apiKey     string
-	httpClient *http.Client
+	providers []TrafficProvider
 
-	// In-memory cache to respect TomTom API rate limits (2,500/day free)
+	// In-memory cache to respect each provider's rate limits (TomTom's free
+	// tier is 2,500/day).
 	mu          sync.RWMutex
 	cachedData  *domain.Traffic
 	cacheExpiry time.Time
 	cacheTTL    time.Duration
 }
 
-// NewTrafficService creates a new traffic service
-func NewTrafficService(apiKey string) *TrafficService {
+// NewTrafficService creates a traffic service that merges flow/incidents from
+// providers, in the order given. An empty providers list is valid and makes
+// GetCurrentTraffic always return simulated data.
+func NewTrafficService(providers ...TrafficProvider) *TrafficService {
 	return &TrafficService{
-		apiKey:     apiKey,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
-		cacheTTL:   15 * time.Minute, // 15 min to stay within TomTom free-tier (2,500/day)
+		providers: providers,
+		cacheTTL:  15 * time.Minute, // 15 min to stay within TomTom free-tier (2,500/day)
 	}
 }
 
-// TomTom API response structs
-
-type TomTomFlowResponse struct {
-	FlowSegmentData struct {
-		CurrentSpeed  float64 `json:"currentSpeed"`
-		FreeFlowSpeed float64 `json:"freeFlowSpeed"`
-		Confidence    float64 `json:"confidence"`
-		RoadClosure   bool    `json:"roadClosure"`
-		Coordinates   struct {
-			Coordinate []struct {
-				Latitude  float64 `json:"latitude"`
-				Longitude float64 `json:"longitude"`
-			} `json:"coordinate"`
-		} `json:"coordinates"`
-	} `json:"flowSegmentData"`
-}
-
-type TomTomIncidentResponse struct {
-	Incidents []TomTomIncident `json:"incidents"`
-}
-
-type TomTomIncident struct {
-	Type     string `json:"type"`
-	Geometry struct {
-		Type        string          `json:"type"`
-		Coordinates json.RawMessage `json:"coordinates"`
-	} `json:"geometry"`
-	Properties struct {
-		ID               string `json:"id"`
-		IconCategory     int    `json:"iconCategory"`
-		MagnitudeOfDelay int    `json:"magnitudeOfDelay"`
-		From             string `json:"from,omitempty"`
-		To               string `json:"to,omitempty"`
-		Delay            int    `json:"delay"`
-		Events           []struct {
-			Description string `json:"description"`
-			Code        int    `json:"code"`
-		} `json:"events"`
-	} `json:"properties"`
-}
-
-// Major Almaty road query points for TomTom Flow API
+// Major Almaty road query points for provider Flow APIs.
 // Expanded network: ~25 major roads covering the full city grid
 var almatyRoadPoints = []struct {
 	name               string
@@ -128,9 +93,21 @@ const (
 	// Almaty roads is 55-65 km/h. Using a higher baseline produces
 	// congestion indices that match perceived congestion (e.g. 2GIS scores).
 	minFreeFlowSpeedKmh = 55.0
+
+	// snapMaxDistanceMeters bounds how far an incident may be from the
+	// nearest road polyline and still be snapped onto it — beyond this it's
+	// more likely off the covered network entirely (e.g. a courtyard or side
+	// street) than imprecise geocoding.
+	snapMaxDistanceMeters = 150.0
+
+	// incidentDedupPrecision rounds incident coordinates to ~111m before
+	// deduping, so the same real-world incident reported by two providers at
+	// slightly different coordinates collapses into one entry.
+	incidentDedupPrecision = 3
 )
 
-// GetCurrentTraffic fetches real traffic data from TomTom API with cache
+// GetCurrentTraffic returns merged traffic data from the configured provider
+// chain, with cache.
 func (s *TrafficService) GetCurrentTraffic(ctx context.Context) (domain.Traffic, error) {
 	// Check cache first (read lock)
 	s.mu.RLock()
@@ -151,17 +128,17 @@ func (s *TrafficService) GetCurrentTraffic(ctx context.Context) (domain.Traffic,
 	// Hold lock during fetch — only one goroutine fetches; others wait.
 	defer s.mu.Unlock()
 
-	// No API key → fallback to simulation
-	if s.apiKey == "" {
-		log.Println("TomTom API key not set, using simulated traffic data")
+	// No providers configured → fallback to simulation
+	if len(s.providers) == 0 {
+		applog.Default().Infof(ctx, "traffic: no providers configured, using simulated traffic data")
 		traffic := s.generateTrafficData()
 		return traffic, nil
 	}
 
-	// Fetch real data from TomTom
-	traffic, err := s.fetchTomTomTraffic(ctx)
+	// Fetch and merge real data from every configured provider
+	traffic, err := s.fetchMergedTraffic(ctx)
 	if err != nil {
-		log.Printf("TomTom API error, falling back to simulation: %v", err)
+		applog.Default().Warnf(ctx, "traffic: providers error, falling back to simulation: %v", err)
 		traffic = s.generateTrafficData()
 		return traffic, nil
 	}
@@ -173,64 +150,110 @@ func (s *TrafficService) GetCurrentTraffic(ctx context.Context) (domain.Traffic,
 	return traffic, nil
 }
 
-// fetchTomTomTraffic queries TomTom APIs for real traffic data
-func (s *TrafficService) fetchTomTomTraffic(ctx context.Context) (domain.Traffic, error) {
+// flowAccumulator collects weighted flow readings for one road across every
+// provider that returned a sample for it.
+type flowAccumulator struct {
+	speedWeighted, freeFlowWeighted, weightSum float64
+	path                                       [][2]float64
+	pathConfidence                             float64
+}
+
+// fetchMergedTraffic queries every configured TrafficProvider for flow and
+// incidents, merges flow into a per-road weighted average by provider
+// Confidence, dedupes incidents via a rounded-coordinate+type snap key, and
+// snaps incidents onto the merged road segments.
+func (s *TrafficService) fetchMergedTraffic(ctx context.Context) (domain.Traffic, error) {
+	points := make([]FlowPoint, len(almatyRoadPoints))
+	for i, road := range almatyRoadPoints {
+		points[i] = FlowPoint{
+			RoadName: road.name,
+			QueryLat: road.queryLat, QueryLon: road.queryLon,
+			StartLat: road.startLat, StartLon: road.startLon,
+			EndLat: road.endLat, EndLon: road.endLon,
+		}
+	}
+	bbox := TrafficBBox{MinLat: almatyMinLat, MinLon: almatyMinLon, MaxLat: almatyMaxLat, MaxLon: almatyMaxLon}
+
+	contributed := make(map[string]bool, len(s.providers))
+	merged := make(map[string]*flowAccumulator, len(points))
+
+	for _, provider := range s.providers {
+		samples, err := provider.FetchFlow(ctx, points)
+		if err != nil {
+			applog.Default().Warnf(ctx, "traffic: %s provider flow query failed, skipping: %v", provider.Name(), err)
+			continue
+		}
+		contributed[provider.Name()] = true
+
+		weight := provider.Confidence()
+		for _, sample := range samples {
+			acc, ok := merged[sample.RoadName]
+			if !ok {
+				acc = &flowAccumulator{}
+				merged[sample.RoadName] = acc
+			}
+			acc.speedWeighted += sample.CurrentSpeed * weight
+			acc.freeFlowWeighted += sample.FreeFlowSpeed * weight
+			acc.weightSum += weight
+			if len(sample.Path) >= 2 && weight > acc.pathConfidence {
+				acc.path = sample.Path
+				acc.pathConfidence = weight
+			}
+		}
+	}
+
+	if len(merged) == 0 {
+		return domain.Traffic{}, fmt.Errorf("all traffic providers failed to return flow data")
+	}
+
 	var totalCurrentSpeed, totalFreeFlowSpeed float64
 	var roadCount int
 	var heatmapPoints []domain.HeatmapPoint
 	var roadSegments []domain.RoadSegment
 
-	// Query flow data for each major road
 	for _, road := range almatyRoadPoints {
-		flow, err := s.queryFlowSegment(ctx, road.queryLat, road.queryLon)
-		if err != nil {
-			log.Printf("TomTom flow query failed for %s: %v", road.name, err)
+		acc, ok := merged[road.name]
+		if !ok || acc.weightSum == 0 {
 			continue
 		}
 
-		currentSpeed := flow.FlowSegmentData.CurrentSpeed
-		freeFlowSpd := flow.FlowSegmentData.FreeFlowSpeed
-		// Use the higher of TomTom's freeflow and our Almaty baseline
-		effectiveFreeFlow := math.Max(freeFlowSpd, minFreeFlowSpeedKmh)
+		currentSpeed := acc.speedWeighted / acc.weightSum
+		freeFlowSpd := math.Max(acc.freeFlowWeighted/acc.weightSum, minFreeFlowSpeedKmh)
 		totalCurrentSpeed += currentSpeed
-		totalFreeFlowSpeed += effectiveFreeFlow
+		totalFreeFlowSpeed += freeFlowSpd
 		roadCount++
 
-		congestion := 1.0 - (currentSpeed / math.Max(effectiveFreeFlow, 1))
+		congestion := 1.0 - (currentSpeed / math.Max(freeFlowSpd, 1))
 		congestion = math.Max(0, math.Min(1, congestion))
 
-		// Build road segment from real coordinates
-		var path [][2]float64
-		if len(flow.FlowSegmentData.Coordinates.Coordinate) > 0 {
-			for _, coord := range flow.FlowSegmentData.Coordinates.Coordinate {
-				path = append(path, [2]float64{coord.Longitude, coord.Latitude})
-				// Also keep heatmap points for backward compat
+		path := acc.path
+		if len(path) >= 2 {
+			for _, coord := range path {
 				heatmapPoints = append(heatmapPoints, domain.HeatmapPoint{
-					Latitude:  coord.Latitude,
-					Longitude: coord.Longitude,
+					Latitude:  coord[1],
+					Longitude: coord[0],
 					Intensity: math.Max(0, math.Min(1, congestion+(rand.Float64()-0.5)*0.1)),
 				})
 			}
 		} else {
-			// Interpolate along road if no coordinates returned
+			// No provider returned segment geometry for this road — interpolate
+			// a straight line between its known endpoints instead.
 			path = interpolatePath(road.startLat, road.startLon, road.endLat, road.endLon, 20)
 			pts := s.interpolateRoadPoints(road.startLat, road.startLon, road.endLat, road.endLon, congestion)
 			heatmapPoints = append(heatmapPoints, pts...)
 		}
 
-		if len(path) >= 2 {
-			roadSegments = append(roadSegments, domain.RoadSegment{
-				Name:       road.name,
-				Path:       path,
-				Congestion: math.Round(congestion*100) / 100,
-				Speed:      math.Round(currentSpeed*10) / 10,
-				FreeFlow:   math.Round(effectiveFreeFlow*10) / 10,
-			})
-		}
+		roadSegments = append(roadSegments, domain.RoadSegment{
+			Name:       road.name,
+			Path:       path,
+			Congestion: math.Round(congestion*100) / 100,
+			Speed:      math.Round(currentSpeed*10) / 10,
+			FreeFlow:   math.Round(freeFlowSpd*10) / 10,
+		})
 	}
 
 	if roadCount == 0 {
-		return domain.Traffic{}, fmt.Errorf("all TomTom flow queries failed")
+		return domain.Traffic{}, fmt.Errorf("all traffic providers returned no usable flow samples")
 	}
 
 	avgCurrentSpeed := totalCurrentSpeed / float64(roadCount)
@@ -248,8 +271,24 @@ func (s *TrafficService) fetchTomTomTraffic(ctx context.Context) (domain.Traffic
 	congestionIndex := scaled * 100
 	congestionIndex = math.Max(0, math.Min(100, congestionIndex))
 
-	// Fetch real incidents
-	incidents := s.fetchTomTomIncidents(ctx)
+	// Gather incidents from every provider, dedupe near-duplicates reported
+	// by more than one source, and snap them onto the merged road polylines.
+	var rawIncidents []domain.Incident
+	for _, provider := range s.providers {
+		incidents, err := provider.FetchIncidents(ctx, bbox)
+		if err != nil {
+			applog.Default().Warnf(ctx, "traffic: %s provider incidents query failed, skipping: %v", provider.Name(), err)
+			continue
+		}
+		if len(incidents) > 0 {
+			contributed[provider.Name()] = true
+			rawIncidents = append(rawIncidents, incidents...)
+		}
+	}
+	incidents := dedupeIncidents(rawIncidents)
+	snapIncidentsToRoads(incidents, roadSegments)
+
+	source := contributingProvidersLabel(contributed)
 
 	traffic := domain.Traffic{
 		CongestionIndex: math.Round(congestionIndex*10) / 10,
@@ -262,155 +301,101 @@ func (s *TrafficService) fetchTomTomTraffic(ctx context.Context) (domain.Traffic
 		IncidentCount:   len(incidents),
 		Timestamp:       time.Now(),
 		IsMock:          false,
+		Source:          source,
 	}
 
-	log.Printf("TomTom traffic: congestion=%.1f%% (raw=%.1f%%), speed=%.1f/%.1f km/h, incidents=%d, segments=%d, heatmap=%d pts",
-		congestionIndex, rawRatio*100, avgCurrentSpeed, avgFreeFlowSpeed, len(incidents), len(roadSegments), len(heatmapPoints))
+	applog.Default().Infof(ctx, "traffic: merged from [%s]: congestion=%.1f%% (raw=%.1f%%), speed=%.1f/%.1f km/h, incidents=%d, segments=%d, heatmap=%d pts",
+		source, congestionIndex, rawRatio*100, avgCurrentSpeed, avgFreeFlowSpeed, len(incidents), len(roadSegments), len(heatmapPoints))
 
 	return traffic, nil
 }
 
-// queryFlowSegment queries TomTom Traffic Flow for a single road point
-func (s *TrafficService) queryFlowSegment(ctx context.Context, lat, lon float64) (*TomTomFlowResponse, error) {
-	url := fmt.Sprintf(
-		"https://api.tomtom.com/traffic/services/4/flowSegmentData/absolute/10/json?point=%f,%f&key=%s&unit=KMPH&thickness=1",
-		lat, lon, s.apiKey,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TomTom Flow API returned status %d", resp.StatusCode)
-	}
-
-	var flowResp TomTomFlowResponse
-	if err := json.NewDecoder(resp.Body).Decode(&flowResp); err != nil {
-		return nil, fmt.Errorf("failed to decode TomTom flow response: %w", err)
+// contributingProvidersLabel joins the names of providers that returned at
+// least one flow sample or incident, sorted for a stable domain.Traffic.Source
+// value across calls.
+func contributingProvidersLabel(contributed map[string]bool) string {
+	names := make([]string, 0, len(contributed))
+	for name := range contributed {
+		names = append(names, name)
 	}
-
-	return &flowResp, nil
+	sort.Strings(names)
+	return strings.Join(names, "+")
 }
 
-// fetchTomTomIncidents queries TomTom Traffic Incidents API v5 for Almaty area
-func (s *TrafficService) fetchTomTomIncidents(ctx context.Context) []domain.Incident {
-	url := fmt.Sprintf(
-		"https://api.tomtom.com/traffic/services/5/incidentDetails?key=%s&bbox=%f,%f,%f,%f&language=ru-RU&categoryFilter=1,6,7,8,9,14&timeValidityFilter=present",
-		s.apiKey, almatyMinLon, almatyMinLat, almatyMaxLon, almatyMaxLat,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		log.Printf("Failed to create incidents request: %v", err)
-		return nil
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		log.Printf("TomTom Incidents API error: %v", err)
-		return nil
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("TomTom Incidents API returned status %d", resp.StatusCode)
-		return nil
-	}
-
-	var incResp TomTomIncidentResponse
-	if err := json.NewDecoder(resp.Body).Decode(&incResp); err != nil {
-		log.Printf("Failed to decode incidents response: %v", err)
-		return nil
-	}
-
-	var incidents []domain.Incident
-	for _, inc := range incResp.Incidents {
-		lat, lon := s.extractIncidentPosition(inc)
-		if lat == 0 && lon == 0 {
+// dedupeIncidents drops incidents that round to the same
+// geoutils.RoundedKey+type as one already kept, so the same real-world
+// incident reported by multiple providers only appears once. The first
+// occurrence (in provider-configured order) wins.
+func dedupeIncidents(incidents []domain.Incident) []domain.Incident {
+	seen := make(map[string]bool, len(incidents))
+	deduped := make([]domain.Incident, 0, len(incidents))
+	for _, inc := range incidents {
+		key := geoutils.RoundedKey(geoutils.Point{Lat: inc.Latitude, Lon: inc.Longitude}, incidentDedupPrecision) + "|" + inc.Type
+		if seen[key] {
 			continue
 		}
-
-		incidents = append(incidents, domain.Incident{
-			Latitude:    lat,
-			Longitude:   lon,
-			Type:        s.mapTomTomCategory(inc.Properties.IconCategory),
-			Description: s.buildIncidentDescription(inc),
-		})
+		seen[key] = true
+		deduped = append(deduped, inc)
 	}
-
-	return incidents
+	return deduped
 }
 
-// extractIncidentPosition gets the first coordinate from incident geometry
-func (s *TrafficService) extractIncidentPosition(inc TomTomIncident) (float64, float64) {
-	// TomTom uses [lon, lat] order in GeoJSON
-	if inc.Geometry.Type == "Point" {
-		var coords [2]float64
-		if err := json.Unmarshal(inc.Geometry.Coordinates, &coords); err == nil {
-			return coords[1], coords[0]
-		}
-	} else if inc.Geometry.Type == "LineString" {
-		var coords [][2]float64
-		if err := json.Unmarshal(inc.Geometry.Coordinates, &coords); err == nil && len(coords) > 0 {
-			return coords[0][1], coords[0][0]
+// snapIncidentsToRoads snaps each incident's coordinates onto the nearest
+// point of the nearest RoadSegment.Path within snapMaxDistanceMeters,
+// attaching the matched road's name. Incidents farther than that from every
+// segment are left at their original (un-snapped) coordinates.
+func snapIncidentsToRoads(incidents []domain.Incident, roadSegments []domain.RoadSegment) {
+	for i := range incidents {
+		point := geoutils.Point{Lat: incidents[i].Latitude, Lon: incidents[i].Longitude}
+
+		bestDistance := math.Inf(1)
+		var bestPoint geoutils.Point
+		var bestRoadName string
+
+		for _, seg := range roadSegments {
+			line := make([]geoutils.Point, len(seg.Path))
+			for j, coord := range seg.Path {
+				line[j] = geoutils.Point{Lat: coord[1], Lon: coord[0]} // Path is [lon, lat]
+			}
+			closest, distance, segIdx := geoutils.ClosestPointOnLineString(point, line)
+			if segIdx == -1 || distance >= bestDistance {
+				continue
+			}
+			bestDistance = distance
+			bestPoint = closest
+			bestRoadName = seg.Name
 		}
-	}
-	return 0, 0
-}
 
-// mapTomTomCategory converts TomTom iconCategory to our incident type
-func (s *TrafficService) mapTomTomCategory(category int) string {
-	switch category {
-	case 1, 14: // Accident, Broken Down Vehicle
-		return "accident"
-	case 9, 7, 8: // Road Works, Lane Closed, Road Closed
-		return "roadwork"
-	default: // Jam, other hazards
-		return "police"
-	}
-}
-
-// buildIncidentDescription creates a human-readable description
-func (s *TrafficService) buildIncidentDescription(inc TomTomIncident) string {
-	desc := ""
-	if len(inc.Properties.Events) > 0 {
-		desc = inc.Properties.Events[0].Description
-	}
-	if inc.Properties.From != "" {
-		if desc != "" {
-			desc += " — "
-		}
-		desc += inc.Properties.From
-		if inc.Properties.To != "" {
-			desc += " → " + inc.Properties.To
+		if bestDistance <= snapMaxDistanceMeters {
+			incidents[i].Latitude = bestPoint.Lat
+			incidents[i].Longitude = bestPoint.Lon
+			incidents[i].RoadName = bestRoadName
 		}
 	}
-	if desc == "" {
-		desc = s.mapTomTomCategory(inc.Properties.IconCategory)
-	}
-	return desc
 }
 
-// interpolateRoadPoints generates heatmap points along a road segment (fallback)
+// interpolateRoadPoints generates heatmap points along a road segment
+// (fallback). Points are jittered off the a-b line for visual spread, then
+// snapped back onto the segment so simulated congestion still renders on
+// the real road polyline instead of drifting into a courtyard.
 func (s *TrafficService) interpolateRoadPoints(x1, y1, x2, y2, congestion float64) []domain.HeatmapPoint {
+	a := geoutils.Point{Lat: x1, Lon: y1}
+	b := geoutils.Point{Lat: x2, Lon: y2}
+	line := []geoutils.Point{a, b}
+
 	numPoints := 30
 	points := make([]domain.HeatmapPoint, 0, numPoints)
 	for i := 0; i < numPoints; i++ {
 		t := float64(i) / float64(numPoints)
-		lat := x1 + t*(x2-x1) + (rand.Float64()-0.5)*0.002
-		lon := y1 + t*(y2-y1) + (rand.Float64()-0.5)*0.002
+		jittered := geoutils.Point{
+			Lat: x1 + t*(x2-x1) + (rand.Float64()-0.5)*0.002,
+			Lon: y1 + t*(y2-y1) + (rand.Float64()-0.5)*0.002,
+		}
+		snapped, _, _ := geoutils.ClosestPointOnLineString(jittered, line)
 		intensity := math.Max(0, math.Min(1, congestion+(rand.Float64()-0.5)*0.15))
 		points = append(points, domain.HeatmapPoint{
-			Latitude:  lat,
-			Longitude: lon,
+			Latitude:  snapped.Lat,
+			Longitude: snapped.Lon,
 			Intensity: intensity,
 		})
 	}
@@ -429,7 +414,7 @@ func interpolatePath(startLat, startLon, endLat, endLon float64, numPoints int)
 	return path
 }
 
-// generateTrafficData creates simulated traffic patterns for Almaty (fallback when API unavailable)
+// generateTrafficData creates simulated traffic patterns for Almaty (fallback when no provider is available)
 func (s *TrafficService) generateTrafficData() domain.Traffic {
 	// Use proper timezone instead of hardcoded UTC+5
 	loc, err := time.LoadLocation("Asia/Almaty")
@@ -507,13 +492,17 @@ func (s *TrafficService) generateIncidents(congestionIndex float64) []domain.Inc
 
 		incType := types[rand.IntN(len(types))]
 		descList := descriptions[incType]
-		desc := descList[rand.IntN(len(descList))]
+		desc := descList[rand.IntN(len(descList))] + " on " + road.name
 
 		incidents = append(incidents, domain.Incident{
 			Latitude:    road.lat + latOffset,
 			Longitude:   road.lon + lonOffset,
 			Type:        incType,
-			Description: desc + " on " + road.name,
+			Description: desc,
+			// Simulated incidents only have an English description; TomTom
+			// incidents carry one per language it returned (see
+			// TomTomTrafficProvider.FetchIncidents), HERE incidents English only.
+			Descriptions: map[language.Tag]string{language.English: desc},
 		})
 	}
 
@@ -527,7 +516,6 @@ func (s *TrafficService) calculateCongestionIndex(hour int, weekday time.Weekday
 		return 25 + rand.Float64()*20
 	}
 
-	// Rush hours
 	// Rush hours
 	switch {
 	case hour >= 7 && hour <= 9: // Morning rush
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// weatherConfigFile is the on-disk shape of the weather provider config
+// YAML, e.g.:
+//
+//	providers:
+//	  - type: open-meteo
+//	    enabled: true
+//	  - type: met-norway
+//	    enabled: false
+//	    user_agent: "smart-city-ai/1.0 ops@example.com"
+type weatherConfigFile struct {
+	Providers []ProviderConfig `yaml:"providers"`
+}
+
+// LoadWeatherServiceConfig parses the provider chain from a YAML file at
+// path. A missing or invalid file is not fatal to callers: they can fall
+// back to the zero-value WeatherServiceConfig{}, which
+// NewWeatherServiceFromConfig turns into the default Open-Meteo-only chain.
+func LoadWeatherServiceConfig(path string) (WeatherServiceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return WeatherServiceConfig{}, fmt.Errorf("weather_service: failed to read config %s: %w", path, err)
+	}
+
+	var parsed weatherConfigFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return WeatherServiceConfig{}, fmt.Errorf("weather_service: failed to parse config %s: %w", path, err)
+	}
+
+	return WeatherServiceConfig{Providers: parsed.Providers}, nil
+}
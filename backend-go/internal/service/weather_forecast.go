@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+const (
+	forecastHourlyTTL = 15 * time.Minute
+	forecastDailyTTL  = 1 * time.Hour
+)
+
+// forecastCache holds the cached forecast alongside the horizon it was
+// requested with, so a later call asking for more hours/days isn't served a
+// stale, too-short cached response.
+type forecastCache struct {
+	mu        sync.RWMutex
+	data      *domain.WeatherForecast
+	hours     int
+	days      int
+	expiresAt time.Time
+}
+
+type openMeteoForecastResponse struct {
+	Hourly struct {
+		Time              []string  `json:"time"`
+		Temperature2m     []float64 `json:"temperature_2m"`
+		PrecipitationProb []int     `json:"precipitation_probability"`
+		WeatherCode       []int     `json:"weather_code"`
+		WindSpeed10m      []float64 `json:"wind_speed_10m"`
+	} `json:"hourly"`
+	Daily struct {
+		Time              []string  `json:"time"`
+		Temperature2mMax  []float64 `json:"temperature_2m_max"`
+		Temperature2mMin  []float64 `json:"temperature_2m_min"`
+		PrecipitationProb []int     `json:"precipitation_probability_max"`
+		WeatherCode       []int     `json:"weather_code"`
+		WindSpeed10mMax   []float64 `json:"wind_speed_10m_max"`
+		Sunrise           []string  `json:"sunrise"`
+		Sunset            []string  `json:"sunset"`
+	} `json:"daily"`
+}
+
+// GetForecast returns hourly and daily forecasts for the next `hours` hours
+// and `days` days. Hourly and daily data are cached together as one entry
+// that expires after forecastHourlyTTL (15 min), the shorter of the two TTLs;
+// daily data is not kept for forecastDailyTTL (1 h) on its own, so it is
+// refetched with the hourly data. A cached entry with a longer horizon also
+// serves shorter requests, trimmed to the requested hours and days.
+func (s *WeatherService) GetForecast(ctx context.Context, hours, days int) (domain.WeatherForecast, error) {
+	if hours <= 0 {
+		hours = 24
+	}
+	if days <= 0 {
+		days = 7
+	}
+
+	s.forecastCache.mu.RLock()
+	if s.forecastCache.data != nil && time.Now().Before(s.forecastCache.expiresAt) &&
+		s.forecastCache.hours >= hours && s.forecastCache.days >= days {
+		cached := *s.forecastCache.data
+		s.forecastCache.mu.RUnlock()
+		if len(cached.Hourly) > hours {
+			cached.Hourly = cached.Hourly[:hours]
+		}
+		if len(cached.Daily) > days {
+			cached.Daily = cached.Daily[:days]
+		}
+		return cached, nil
+	}
+	s.forecastCache.mu.RUnlock()
+
+	forecast, err := s.fetchOpenMeteoForecast(ctx, hours, days)
+	if err != nil {
+		return s.getMockForecast(hours, days), nil
+	}
+
+	// One cache entry holds both horizons, so it takes the shorter of the two
+	// TTLs; the hours/days comparison above only checks horizon length.
+	ttl := forecastHourlyTTL
+	if ttl > forecastDailyTTL {
+		ttl = forecastDailyTTL
+	}
+
+	s.forecastCache.mu.Lock()
+	s.forecastCache.data = &forecast
+	s.forecastCache.hours = hours
+	s.forecastCache.days = days
+	s.forecastCache.expiresAt = time.Now().Add(ttl)
+	s.forecastCache.mu.Unlock()
+
+	return forecast, nil
+}
+
+func (s *WeatherService) fetchOpenMeteoForecast(ctx context.Context, hours, days int) (domain.WeatherForecast, error) {
+	url := fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f"+
+			"&hourly=temperature_2m,precipitation_probability,weather_code,wind_speed_10m"+
+			"&daily=temperature_2m_max,temperature_2m_min,precipitation_probability_max,weather_code,wind_speed_10m_max,sunrise,sunset"+
+			"&forecast_hours=%d&forecast_days=%d&timezone=Asia%%2FAlmaty",
+		domain.AlmatyCenterLat, domain.AlmatyCenterLon, hours, days,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.WeatherForecast{}, fmt.Errorf("open-meteo forecast: create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return domain.WeatherForecast{}, fmt.Errorf("open-meteo forecast: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.WeatherForecast{}, fmt.Errorf("open-meteo forecast: status %d", resp.StatusCode)
+	}
+
+	var omResp openMeteoForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&omResp); err != nil {
+		return domain.WeatherForecast{}, fmt.Errorf("open-meteo forecast: decode: %w", err)
+	}
+
+	hourly := make([]domain.ForecastHour, 0, len(omResp.Hourly.Time))
+	for i, t := range omResp.Hourly.Time {
+		parsed, _ := time.Parse("2006-01-02T15:04", t)
+		code := valueAt(omResp.Hourly.WeatherCode, i)
+		description, icon := wmoToDescription(code)
+		hourly = append(hourly, domain.ForecastHour{
+			Time:              parsed,
+			Temperature:       math.Round(valueAtF(omResp.Hourly.Temperature2m, i)*10) / 10,
+			PrecipProbability: valueAt(omResp.Hourly.PrecipitationProb, i),
+			PrecipType:        precipType(code),
+			WindSpeed:         math.Round(valueAtF(omResp.Hourly.WindSpeed10m, i)/3.6*10) / 10,
+			WeatherCode:       code,
+			Description:       description,
+			Icon:              icon,
+		})
+	}
+
+	daily := make([]domain.ForecastDay, 0, len(omResp.Daily.Time))
+	for i, d := range omResp.Daily.Time {
+		code := valueAt(omResp.Daily.WeatherCode, i)
+		description, icon := wmoToDescription(code)
+		daily = append(daily, domain.ForecastDay{
+			Date:              d,
+			TempMin:           math.Round(valueAtF(omResp.Daily.Temperature2mMin, i)*10) / 10,
+			TempMax:           math.Round(valueAtF(omResp.Daily.Temperature2mMax, i)*10) / 10,
+			PrecipProbability: valueAt(omResp.Daily.PrecipitationProb, i),
+			PrecipType:        precipType(code),
+			WindSpeed:         math.Round(valueAtF(omResp.Daily.WindSpeed10mMax, i)/3.6*10) / 10,
+			WeatherCode:       code,
+			Description:       description,
+			Icon:              icon,
+			Sunrise:           valueAtS(omResp.Daily.Sunrise, i),
+			Sunset:            valueAtS(omResp.Daily.Sunset, i),
+			MoonPhase:         moonPhase(time.Now()),
+		})
+	}
+
+	return domain.WeatherForecast{
+		Hourly:    hourly,
+		Daily:     daily,
+		Timestamp: time.Now(),
+		IsMock:    false,
+	}, nil
+}
+
+// getMockForecast returns a simple simulated forecast when Open-Meteo is
+// unavailable, extrapolating from getMockWeather's seasonal baseline.
+func (s *WeatherService) getMockForecast(hours, days int) domain.WeatherForecast {
+	base := s.getMockWeather()
+	now := time.Now()
+
+	hourly := make([]domain.ForecastHour, 0, hours)
+	for i := 0; i < hours; i++ {
+		hourly = append(hourly, domain.ForecastHour{
+			Time:              now.Add(time.Duration(i) * time.Hour),
+			Temperature:       base.Temperature,
+			PrecipProbability: 20,
+			WindSpeed:         base.WindSpeed,
+			Description:       base.Description,
+			Icon:              base.Icon,
+		})
+	}
+
+	daily := make([]domain.ForecastDay, 0, days)
+	for i := 0; i < days; i++ {
+		day := now.AddDate(0, 0, i)
+		daily = append(daily, domain.ForecastDay{
+			Date:              day.Format("2006-01-02"),
+			TempMin:           base.Temperature - 4,
+			TempMax:           base.Temperature + 4,
+			PrecipProbability: 20,
+			WindSpeed:         base.WindSpeed,
+			Description:       base.Description,
+			Icon:              base.Icon,
+			MoonPhase:         moonPhase(day),
+		})
+	}
+
+	return domain.WeatherForecast{Hourly: hourly, Daily: daily, Timestamp: now, IsMock: true}
+}
+
+// precipType gives a coarse precipitation type from a WMO weather code, used
+// when the provider doesn't break it out separately.
+func precipType(code int) string {
+	switch {
+	case code >= 71 && code <= 77 || code >= 85 && code <= 86:
+		return "snow"
+	case code >= 51 && code <= 67 || code >= 80 && code <= 82:
+		return "rain"
+	case code >= 95:
+		return "thunderstorm"
+	default:
+		return ""
+	}
+}
+
+// moonPhase approximates the moon's phase (0.0 new, 0.5 full, back to 1.0≈0.0)
+// using a fixed synodic period anchored to a known new moon.
+func moonPhase(t time.Time) float64 {
+	const synodicMonth = 29.530588853
+	knownNewMoon := time.Date(2000, 1, 6, 18, 14, 0, 0, time.UTC)
+	days := t.Sub(knownNewMoon).Hours() / 24
+	phase := math.Mod(days, synodicMonth) / synodicMonth
+	if phase < 0 {
+		phase += 1
+	}
+	return math.Round(phase*1000) / 1000
+}
+
+func valueAt(s []int, i int) int {
+	if i < 0 || i >= len(s) {
+		return 0
+	}
+	return s[i]
+}
+
+func valueAtF(s []float64, i int) float64 {
+	if i < 0 || i >= len(s) {
+		return 0
+	}
+	return s[i]
+}
+
+func valueAtS(s []string, i int) string {
+	if i < 0 || i >= len(s) {
+		return ""
+	}
+	return s[i]
+}
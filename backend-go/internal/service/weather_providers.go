@@ -0,0 +1,738 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcity/backend/internal/domain"
+)
+
+// WeatherProvider fetches current weather for a location from a single
+// upstream source. WeatherService tries providers in configured order and
+// falls back to the next one on error, so every implementation must return
+// a non-nil error (instead of partial/zero data) when it cannot serve a
+// reading.
+type WeatherProvider interface {
+	// Name identifies the provider, e.g. "open-meteo", used as domain.Weather.Source
+	// and as the key in WeatherService's per-provider health map.
+	Name() string
+	// Fetch returns current weather for the given coordinates, normalized to
+	// °C, hPa, and m/s regardless of the upstream provider's native units.
+	Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error)
+}
+
+// ---------------------------------------------------------------------------
+// Open-Meteo
+// ---------------------------------------------------------------------------
+
+// OpenMeteoProvider fetches current weather from Open-Meteo (free, no API key).
+type OpenMeteoProvider struct {
+	httpClient *http.Client
+}
+
+// NewOpenMeteoProvider creates an Open-Meteo provider.
+func NewOpenMeteoProvider(httpClient *http.Client) *OpenMeteoProvider {
+	return &OpenMeteoProvider{httpClient: httpClient}
+}
+
+func (p *OpenMeteoProvider) Name() string { return "open-meteo" }
+
+func (p *OpenMeteoProvider) Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error) {
+	url := fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&current=temperature_2m,relative_humidity_2m,apparent_temperature,weather_code,wind_speed_10m,surface_pressure&timezone=Asia%%2FAlmaty",
+		lat, lon,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("open-meteo: create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("open-meteo: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Weather{}, fmt.Errorf("open-meteo: status %d", resp.StatusCode)
+	}
+
+	var omResp OpenMeteoCurrentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&omResp); err != nil {
+		return domain.Weather{}, fmt.Errorf("open-meteo: decode: %w", err)
+	}
+
+	c := omResp.Current
+	description, icon := wmoToDescription(c.WeatherCode)
+
+	return domain.Weather{
+		Temperature: math.Round(c.Temperature2m*10) / 10,
+		FeelsLike:   math.Round(c.ApparentTemp*10) / 10,
+		Humidity:    c.RelativeHumidity2m,
+		Description: description,
+		Icon:        icon,
+		WindSpeed:   math.Round(c.WindSpeed10m/3.6*10) / 10, // km/h → m/s
+		Visibility:  10000,
+		Pressure:    int(math.Round(c.SurfacePressure)),
+		Timestamp:   time.Now(),
+		IsMock:      false,
+		Source:      p.Name(),
+	}, nil
+}
+
+// ---------------------------------------------------------------------------
+// OpenWeatherMap
+// ---------------------------------------------------------------------------
+
+// OpenWeatherMapProvider fetches current weather from the OpenWeatherMap
+// Current Weather Data API, addressed either by city ID or lat/lon — the
+// same addressing modes the Telegraf openweathermap plugin supports.
+type OpenWeatherMapProvider struct {
+	httpClient *http.Client
+	apiKey     string
+	cityID     string // optional, takes priority over lat/lon when set
+	units      string // "metric", "imperial", or "standard"
+}
+
+// NewOpenWeatherMapProvider creates an OpenWeatherMap provider. units
+// defaults to "metric" (°C, m/s) when empty.
+func NewOpenWeatherMapProvider(httpClient *http.Client, apiKey, cityID, units string) *OpenWeatherMapProvider {
+	if units == "" {
+		units = "metric"
+	}
+	return &OpenWeatherMapProvider{httpClient: httpClient, apiKey: apiKey, cityID: cityID, units: units}
+}
+
+func (p *OpenWeatherMapProvider) Name() string { return "openweathermap" }
+
+type openWeatherMapResponse struct {
+	Weather []struct {
+		Description string `json:"description"`
+		Icon        string `json:"icon"`
+	} `json:"weather"`
+	Main struct {
+		Temp      float64 `json:"temp"`
+		FeelsLike float64 `json:"feels_like"`
+		Pressure  int     `json:"pressure"`
+		Humidity  int     `json:"humidity"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Visibility int `json:"visibility"`
+	Sys        struct {
+		Country string `json:"country"`
+	} `json:"sys"`
+	Name string `json:"name"`
+}
+
+func (p *OpenWeatherMapProvider) Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error) {
+	if p.apiKey == "" {
+		return domain.Weather{}, fmt.Errorf("openweathermap: no API key configured")
+	}
+
+	var url string
+	if p.cityID != "" {
+		url = fmt.Sprintf(
+			"https://api.openweathermap.org/data/2.5/weather?id=%s&units=%s&appid=%s",
+			p.cityID, p.units, p.apiKey,
+		)
+	} else {
+		url = fmt.Sprintf(
+			"https://api.openweathermap.org/data/2.5/weather?lat=%.4f&lon=%.4f&units=%s&appid=%s",
+			lat, lon, p.units, p.apiKey,
+		)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("openweathermap: create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("openweathermap: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Weather{}, fmt.Errorf("openweathermap: status %d", resp.StatusCode)
+	}
+
+	var owResp openWeatherMapResponse
+	if err := json.NewDecoder(resp.Body).Decode(&owResp); err != nil {
+		return domain.Weather{}, fmt.Errorf("openweathermap: decode: %w", err)
+	}
+
+	description, icon := "", ""
+	if len(owResp.Weather) > 0 {
+		description = owResp.Weather[0].Description
+		icon = owResp.Weather[0].Icon
+	}
+
+	temp, feelsLike, windSpeed := owResp.Main.Temp, owResp.Main.FeelsLike, owResp.Wind.Speed
+	switch p.units {
+	case "imperial": // °F, mph → normalize to °C, m/s
+		temp = (temp - 32) * 5 / 9
+		feelsLike = (feelsLike - 32) * 5 / 9
+		windSpeed = windSpeed * 0.44704
+	case "standard": // Kelvin, m/s → normalize to °C
+		temp -= 273.15
+		feelsLike -= 273.15
+	}
+
+	return domain.Weather{
+		Temperature: math.Round(temp*10) / 10,
+		FeelsLike:   math.Round(feelsLike*10) / 10,
+		Humidity:    owResp.Main.Humidity,
+		Description: strings.Title(description),
+		Icon:        icon,
+		WindSpeed:   math.Round(windSpeed*10) / 10,
+		Visibility:  owResp.Visibility,
+		Pressure:    owResp.Main.Pressure,
+		City:        owResp.Name,
+		Country:     owResp.Sys.Country,
+		Timestamp:   time.Now(),
+		IsMock:      false,
+		Source:      p.Name(),
+	}, nil
+}
+
+// ---------------------------------------------------------------------------
+// MET Norway (Locationforecast)
+// ---------------------------------------------------------------------------
+
+// MetNoProvider fetches current weather from the MET Norway Locationforecast
+// API. Their Terms of Service require a descriptive User-Agent identifying
+// the application/contact, and reward conditional requests (If-Modified-Since
+// against the last response's Expires/Last-Modified) with free 304s.
+type MetNoProvider struct {
+	httpClient *http.Client
+	userAgent  string
+
+	// mu guards lastModified/expires/cached: WeatherService's cache-miss path
+	// can call Fetch on the same provider from multiple goroutines at once.
+	mu           sync.Mutex
+	lastModified string
+	expires      time.Time
+	cached       *domain.Weather
+}
+
+// NewMetNoProvider creates a MET Norway provider. userAgent must identify the
+// calling application per met.no's ToS, e.g. "smart-city-ai/1.0 contact@example.com".
+func NewMetNoProvider(httpClient *http.Client, userAgent string) *MetNoProvider {
+	return &MetNoProvider{httpClient: httpClient, userAgent: userAgent}
+}
+
+func (p *MetNoProvider) Name() string { return "met-norway" }
+
+type metNoResponse struct {
+	Properties struct {
+		Timeseries []struct {
+			Time string `json:"time"`
+			Data struct {
+				Instant struct {
+					Details struct {
+						AirTemperature        float64 `json:"air_temperature"`
+						RelativeHumidity      float64 `json:"relative_humidity"`
+						WindSpeed             float64 `json:"wind_speed"`
+						AirPressureAtSeaLevel float64 `json:"air_pressure_at_sea_level"`
+					} `json:"details"`
+				} `json:"instant"`
+				Next1Hours struct {
+					Summary struct {
+						SymbolCode string `json:"symbol_code"`
+					} `json:"summary"`
+				} `json:"next_1_hours"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// Fetch is guarded by p.mu end-to-end: this provider can be called directly
+// (bypassing WeatherService's own cache lock) via GetWeatherFromProvider, so
+// lastModified/expires/cached need their own protection against concurrent
+// callers rather than relying on the caller to serialize access.
+func (p *MetNoProvider) Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.userAgent == "" {
+		return domain.Weather{}, fmt.Errorf("met-norway: User-Agent is required by met.no ToS")
+	}
+
+	// Honor a still-fresh cached response rather than issuing a new request.
+	if p.cached != nil && time.Now().Before(p.expires) {
+		cached := *p.cached
+		return cached, nil
+	}
+
+	url := fmt.Sprintf("https://api.met.no/weatherapi/locationforecast/2.0/compact?lat=%.4f&lon=%.4f", lat, lon)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("met-norway: create request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	if p.lastModified != "" {
+		req.Header.Set("If-Modified-Since", p.lastModified)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("met-norway: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && p.cached != nil {
+		p.updateCacheControlLocked(resp)
+		cached := *p.cached
+		return cached, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Weather{}, fmt.Errorf("met-norway: status %d", resp.StatusCode)
+	}
+
+	var mnResp metNoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mnResp); err != nil {
+		return domain.Weather{}, fmt.Errorf("met-norway: decode: %w", err)
+	}
+	if len(mnResp.Properties.Timeseries) == 0 {
+		return domain.Weather{}, fmt.Errorf("met-norway: empty timeseries")
+	}
+
+	d := mnResp.Properties.Timeseries[0].Data
+	description, icon := metNoSymbolToDescription(d.Next1Hours.Summary.SymbolCode)
+
+	weather := domain.Weather{
+		Temperature: math.Round(d.Instant.Details.AirTemperature*10) / 10,
+		FeelsLike:   math.Round(d.Instant.Details.AirTemperature*10) / 10,
+		Humidity:    int(math.Round(d.Instant.Details.RelativeHumidity)),
+		Description: description,
+		Icon:        icon,
+		WindSpeed:   math.Round(d.Instant.Details.WindSpeed*10) / 10, // already m/s
+		Visibility:  10000,
+		Pressure:    int(math.Round(d.Instant.Details.AirPressureAtSeaLevel)),
+		Timestamp:   time.Now(),
+		IsMock:      false,
+		Source:      p.Name(),
+	}
+
+	p.cached = &weather
+	p.updateCacheControlLocked(resp)
+
+	return weather, nil
+}
+
+// updateCacheControlLocked updates lastModified/expires from resp. Callers
+// must hold p.mu.
+func (p *MetNoProvider) updateCacheControlLocked(resp *http.Response) {
+	if lm := resp.Header.Get("Last-Modified"); lm != "" {
+		p.lastModified = lm
+	}
+	if exp := resp.Header.Get("Expires"); exp != "" {
+		if t, err := time.Parse(http.TimeFormat, exp); err == nil {
+			p.expires = t
+		}
+	}
+}
+
+func metNoSymbolToDescription(symbol string) (string, string) {
+	base := strings.SplitN(symbol, "_", 2)[0]
+	switch base {
+	case "clearsky":
+		return "Clear sky", "01d"
+	case "fair":
+		return "Fair", "02d"
+	case "partlycloudy":
+		return "Partly cloudy", "02d"
+	case "cloudy":
+		return "Cloudy", "04d"
+	case "fog":
+		return "Fog", "50d"
+	case "rain", "lightrain", "heavyrain":
+		return "Rain", "10d"
+	case "snow", "lightsnow", "heavysnow":
+		return "Snow", "13d"
+	case "rainshowers", "lightrainshowers", "heavyrainshowers":
+		return "Rain showers", "09d"
+	case "thunder":
+		return "Thunderstorm", "11d"
+	default:
+		return "Unknown", "04d"
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NOAA METAR (aviationweather.gov)
+// ---------------------------------------------------------------------------
+
+// NOAAMetarProvider fetches the latest METAR observation for an airport
+// station (e.g. "KSEA") from aviationweather.gov's XML data feed.
+type NOAAMetarProvider struct {
+	httpClient  *http.Client
+	stationCode string
+}
+
+// NewNOAAMetarProvider creates a NOAA METAR provider for the given ICAO
+// station code, e.g. "KSEA".
+func NewNOAAMetarProvider(httpClient *http.Client, stationCode string) *NOAAMetarProvider {
+	return &NOAAMetarProvider{httpClient: httpClient, stationCode: strings.ToUpper(stationCode)}
+}
+
+func (p *NOAAMetarProvider) Name() string { return "noaa-metar" }
+
+// metarXML mirrors aviationweather.gov's legacy METAR XML schema.
+type metarXML struct {
+	XMLName xml.Name `xml:"response"`
+	Data    struct {
+		METAR []struct {
+			StationID   string  `xml:"station_id"`
+			TempC       float64 `xml:"temp_c"`
+			DewpointC   float64 `xml:"dewpoint_c"`
+			WindSpeedKt float64 `xml:"wind_speed_kt"`
+			VisStatMi   string  `xml:"visibility_statute_mi"`
+			AltimInHg   float64 `xml:"altim_in_hg"`
+			WxString    string  `xml:"wx_string"`
+		} `xml:"METAR"`
+	} `xml:"data"`
+}
+
+func (p *NOAAMetarProvider) Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error) {
+	if p.stationCode == "" {
+		return domain.Weather{}, fmt.Errorf("noaa-metar: no station code configured")
+	}
+
+	url := fmt.Sprintf(
+		"https://aviationweather.gov/cgi-bin/data/metar.php?ids=%s&format=xml&hours=0",
+		p.stationCode,
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("noaa-metar: create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("noaa-metar: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Weather{}, fmt.Errorf("noaa-metar: status %d", resp.StatusCode)
+	}
+
+	var parsed metarXML
+	if err := xml.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return domain.Weather{}, fmt.Errorf("noaa-metar: decode xml: %w", err)
+	}
+	if len(parsed.Data.METAR) == 0 {
+		return domain.Weather{}, fmt.Errorf("noaa-metar: no observation for station %s", p.stationCode)
+	}
+
+	m := parsed.Data.METAR[0]
+	visibilityMi, _ := strconv.ParseFloat(strings.TrimPrefix(m.VisStatMi, "+"), 64)
+
+	return domain.Weather{
+		Temperature: math.Round(m.TempC*10) / 10,
+		FeelsLike:   math.Round(m.TempC*10) / 10,
+		Humidity:    relativeHumidityFromDewpoint(m.TempC, m.DewpointC),
+		Description: metarWxToDescription(m.WxString),
+		Icon:        "50d",
+		WindSpeed:   math.Round(m.WindSpeedKt*0.514444*10) / 10, // kt → m/s
+		Visibility:  int(math.Round(visibilityMi * 1609.34)),    // statute miles → m
+		Pressure:    int(math.Round(m.AltimInHg * 33.8639)),     // inHg → hPa
+		City:        m.StationID,
+		Timestamp:   time.Now(),
+		IsMock:      false,
+		Source:      p.Name(),
+	}, nil
+}
+
+// relativeHumidityFromDewpoint approximates RH% from the Magnus formula,
+// since METAR reports dewpoint rather than humidity directly.
+func relativeHumidityFromDewpoint(tempC, dewpointC float64) int {
+	es := func(t float64) float64 { return 6.112 * math.Exp(17.67*t/(t+243.5)) }
+	rh := 100 * es(dewpointC) / es(tempC)
+	return int(math.Round(math.Max(0, math.Min(100, rh))))
+}
+
+func metarWxToDescription(wx string) string {
+	switch {
+	case wx == "":
+		return "Clear"
+	case strings.Contains(wx, "TS"):
+		return "Thunderstorm"
+	case strings.Contains(wx, "SN"):
+		return "Snow"
+	case strings.Contains(wx, "RA"):
+		return "Rain"
+	case strings.Contains(wx, "FG"):
+		return "Fog"
+	case strings.Contains(wx, "BR"):
+		return "Mist"
+	default:
+		return wx
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NWS (api.weather.gov)
+// ---------------------------------------------------------------------------
+
+// NWSProvider fetches the latest observation from the U.S. National Weather
+// Service's api.weather.gov, which (like met.no) requires an identifying
+// User-Agent. Coordinates only resolve to a station once per process, since
+// the points->stations lookup rarely changes for a fixed location.
+type NWSProvider struct {
+	httpClient *http.Client
+	userAgent  string
+
+	mu        sync.Mutex
+	stationID string
+}
+
+// NewNWSProvider creates an NWS provider. userAgent must identify the calling
+// application per api.weather.gov's usage policy, e.g.
+// "smart-city-ai/1.0 contact@example.com".
+func NewNWSProvider(httpClient *http.Client, userAgent string) *NWSProvider {
+	return &NWSProvider{httpClient: httpClient, userAgent: userAgent}
+}
+
+func (p *NWSProvider) Name() string { return "nws" }
+
+type nwsPointsResponse struct {
+	Properties struct {
+		ObservationStations string `json:"observationStations"`
+	} `json:"properties"`
+}
+
+type nwsStationsResponse struct {
+	Features []struct {
+		Properties struct {
+			StationIdentifier string `json:"stationIdentifier"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+type nwsQuantity struct {
+	Value *float64 `json:"value"`
+}
+
+type nwsObservationResponse struct {
+	Properties struct {
+		TextDescription    string      `json:"textDescription"`
+		Temperature        nwsQuantity `json:"temperature"`        // degC
+		RelativeHumidity   nwsQuantity `json:"relativeHumidity"`   // percent
+		WindSpeed          nwsQuantity `json:"windSpeed"`          // km/h
+		BarometricPressure nwsQuantity `json:"barometricPressure"` // Pa
+		Visibility         nwsQuantity `json:"visibility"`         // m
+	} `json:"properties"`
+}
+
+func (p *NWSProvider) Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error) {
+	if p.userAgent == "" {
+		return domain.Weather{}, fmt.Errorf("nws: User-Agent is required by api.weather.gov")
+	}
+
+	stationID, err := p.resolveStation(ctx, lat, lon)
+	if err != nil {
+		return domain.Weather{}, err
+	}
+
+	var obs nwsObservationResponse
+	if err := p.getJSON(ctx, fmt.Sprintf("https://api.weather.gov/stations/%s/observations/latest", stationID), &obs); err != nil {
+		return domain.Weather{}, err
+	}
+	if obs.Properties.Temperature.Value == nil {
+		return domain.Weather{}, fmt.Errorf("nws: station %s has no current temperature reading", stationID)
+	}
+
+	temp := *obs.Properties.Temperature.Value
+	humidity := 0
+	if obs.Properties.RelativeHumidity.Value != nil {
+		humidity = int(math.Round(*obs.Properties.RelativeHumidity.Value))
+	}
+	windSpeed := 0.0
+	if obs.Properties.WindSpeed.Value != nil {
+		windSpeed = math.Round(*obs.Properties.WindSpeed.Value/3.6*10) / 10 // km/h -> m/s
+	}
+	pressure := 0
+	if obs.Properties.BarometricPressure.Value != nil {
+		pressure = int(math.Round(*obs.Properties.BarometricPressure.Value / 100)) // Pa -> hPa
+	}
+	visibility := 10000
+	if obs.Properties.Visibility.Value != nil {
+		visibility = int(math.Round(*obs.Properties.Visibility.Value))
+	}
+
+	return domain.Weather{
+		Temperature: math.Round(temp*10) / 10,
+		FeelsLike:   math.Round(temp*10) / 10,
+		Humidity:    humidity,
+		Description: obs.Properties.TextDescription,
+		WindSpeed:   windSpeed,
+		Visibility:  visibility,
+		Pressure:    pressure,
+		City:        stationID,
+		Timestamp:   time.Now(),
+		IsMock:      false,
+		Source:      p.Name(),
+	}, nil
+}
+
+// resolveStation looks up the nearest observation station for (lat, lon) via
+// the /points and /stations endpoints, caching the result for the life of
+// the provider since a fixed location's nearest station never changes.
+func (p *NWSProvider) resolveStation(ctx context.Context, lat, lon float64) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stationID != "" {
+		return p.stationID, nil
+	}
+
+	var points nwsPointsResponse
+	pointsURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
+	if err := p.getJSON(ctx, pointsURL, &points); err != nil {
+		return "", err
+	}
+	if points.Properties.ObservationStations == "" {
+		return "", fmt.Errorf("nws: no observation stations for %.4f,%.4f", lat, lon)
+	}
+
+	var stations nwsStationsResponse
+	if err := p.getJSON(ctx, points.Properties.ObservationStations, &stations); err != nil {
+		return "", err
+	}
+	if len(stations.Features) == 0 {
+		return "", fmt.Errorf("nws: station list empty for %.4f,%.4f", lat, lon)
+	}
+
+	p.stationID = stations.Features[0].Properties.StationIdentifier
+	return p.stationID, nil
+}
+
+func (p *NWSProvider) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("nws: create request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	req.Header.Set("Accept", "application/geo+json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("nws: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("nws: %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("nws: decode %s: %w", url, err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// World Weather Online
+// ---------------------------------------------------------------------------
+
+// WorldWeatherOnlineProvider fetches current weather from World Weather
+// Online's premium API.
+type WorldWeatherOnlineProvider struct {
+	httpClient *http.Client
+	apiKey     string
+}
+
+// NewWorldWeatherOnlineProvider creates a World Weather Online provider.
+func NewWorldWeatherOnlineProvider(httpClient *http.Client, apiKey string) *WorldWeatherOnlineProvider {
+	return &WorldWeatherOnlineProvider{httpClient: httpClient, apiKey: apiKey}
+}
+
+func (p *WorldWeatherOnlineProvider) Name() string { return "world-weather-online" }
+
+type worldWeatherOnlineResponse struct {
+	Data struct {
+		CurrentCondition []struct {
+			TempC         string `json:"temp_C"`
+			FeelsLikeC    string `json:"FeelsLikeC"`
+			Humidity      string `json:"humidity"`
+			WindspeedKmph string `json:"windspeedKmph"`
+			VisibilityKm  string `json:"visibility"`
+			Pressure      string `json:"pressure"`
+			WeatherDesc   []struct {
+				Value string `json:"value"`
+			} `json:"weatherDesc"`
+		} `json:"current_condition"`
+	} `json:"data"`
+}
+
+func (p *WorldWeatherOnlineProvider) Fetch(ctx context.Context, lat, lon float64) (domain.Weather, error) {
+	if p.apiKey == "" {
+		return domain.Weather{}, fmt.Errorf("world-weather-online: no API key configured")
+	}
+
+	url := fmt.Sprintf(
+		"https://api.worldweatheronline.com/premium/v1/weather.ashx?key=%s&q=%.4f,%.4f&format=json&num_of_days=1",
+		p.apiKey, lat, lon,
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("world-weather-online: create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.Weather{}, fmt.Errorf("world-weather-online: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Weather{}, fmt.Errorf("world-weather-online: status %d", resp.StatusCode)
+	}
+
+	var wwoResp worldWeatherOnlineResponse
+	if err := json.NewDecoder(resp.Body).Decode(&wwoResp); err != nil {
+		return domain.Weather{}, fmt.Errorf("world-weather-online: decode: %w", err)
+	}
+	if len(wwoResp.Data.CurrentCondition) == 0 {
+		return domain.Weather{}, fmt.Errorf("world-weather-online: empty current_condition")
+	}
+
+	cc := wwoResp.Data.CurrentCondition[0]
+	temp, _ := strconv.ParseFloat(cc.TempC, 64)
+	feelsLike, _ := strconv.ParseFloat(cc.FeelsLikeC, 64)
+	humidity, _ := strconv.Atoi(cc.Humidity)
+	windKmph, _ := strconv.ParseFloat(cc.WindspeedKmph, 64)
+	visibilityKm, _ := strconv.ParseFloat(cc.VisibilityKm, 64)
+	pressure, _ := strconv.Atoi(cc.Pressure)
+
+	description := ""
+	if len(cc.WeatherDesc) > 0 {
+		description = cc.WeatherDesc[0].Value
+	}
+
+	return domain.Weather{
+		Temperature: math.Round(temp*10) / 10,
+		FeelsLike:   math.Round(feelsLike*10) / 10,
+		Humidity:    humidity,
+		Description: description,
+		WindSpeed:   math.Round(windKmph/3.6*10) / 10, // km/h -> m/s
+		Visibility:  int(math.Round(visibilityKm * 1000)),
+		Pressure:    pressure,
+		Timestamp:   time.Now(),
+		IsMock:      false,
+		Source:      p.Name(),
+	}, nil
+}
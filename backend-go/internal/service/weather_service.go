@@ -5,36 +5,153 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"math"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/smartcity/backend/internal/domain"
+	"github.com/smartcity/backend/internal/httpcache"
+	"github.com/smartcity/backend/internal/metrics"
 )
 
-// WeatherService fetches weather + AQI from Open-Meteo (free, no API key).
-// Replaces OpenWeatherMap to avoid rate limits (1,000/day).
-// Open-Meteo allows 10,000+ requests/day, no key needed.
+// ProviderHealth records the outcome of the most recent attempt to fetch
+// from a single WeatherProvider, surfaced on GET /health.
+type ProviderHealth struct {
+	Name        string    `json:"name"`
+	Healthy     bool      `json:"healthy"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// ProviderConfig configures a single WeatherProvider entry in
+// WeatherServiceConfig. Only the fields relevant to Type are read.
+type ProviderConfig struct {
+	Type        string `json:"type" yaml:"type"` // "open-meteo", "openweathermap", "met-norway", "nws", "world-weather-online", "noaa-metar"
+	Enabled     bool   `json:"enabled" yaml:"enabled"`
+	APIKey      string `json:"api_key,omitempty" yaml:"api_key,omitempty"`           // openweathermap, world-weather-online
+	CityID      string `json:"city_id,omitempty" yaml:"city_id,omitempty"`           // openweathermap
+	Units       string `json:"units,omitempty" yaml:"units,omitempty"`               // openweathermap: metric/imperial/standard
+	UserAgent   string `json:"user_agent,omitempty" yaml:"user_agent,omitempty"`     // met-norway, nws
+	StationCode string `json:"station_code,omitempty" yaml:"station_code,omitempty"` // noaa-metar
+}
+
+// WeatherServiceConfig lists the providers WeatherService should try, in
+// priority order, along with per-provider credentials. Loaded from a YAML
+// file by LoadWeatherServiceConfig (weather_config.go).
+type WeatherServiceConfig struct {
+	Providers []ProviderConfig `json:"providers" yaml:"providers"`
+}
+
+// WeatherService fetches current weather by trying a chain of
+// WeatherProviders in configured order, falling back to the next on
+// error/timeout, and normalizing units centrally before caching the result.
 type WeatherService struct {
 	httpClient *http.Client
+	providers  []WeatherProvider
 
 	// Cache to avoid excessive API calls
 	mu          sync.RWMutex
 	cachedData  *domain.Weather
 	cacheExpiry time.Time
 	cacheTTL    time.Duration
+
+	healthMu sync.RWMutex
+	health   map[string]ProviderHealth
+
+	forecastCache forecastCache
+}
+
+// NewWeatherService creates a weather service with the default single-provider
+// chain (Open-Meteo only). The apiKey param is kept for backward compatibility
+// but is unused — use NewWeatherServiceFromConfig to configure additional
+// providers with failover. cacheStore backs conditional-request caching for
+// every provider's HTTP calls (see internal/httpcache); pass
+// httpcache.NewMemoryStore(0) for a process-local cache with no persistence.
+func NewWeatherService(apiKey string, cacheStore httpcache.Store) *WeatherService {
+	httpClient := newCachingHTTPClient(10*time.Second, cacheStore)
+	return newWeatherService(httpClient, []WeatherProvider{NewOpenMeteoProvider(httpClient)})
+}
+
+// NewWeatherServiceFromConfig builds the provider chain described by cfg, in
+// the order given, skipping disabled entries.
+func NewWeatherServiceFromConfig(cfg WeatherServiceConfig, cacheStore httpcache.Store) (*WeatherService, error) {
+	httpClient := newCachingHTTPClient(10*time.Second, cacheStore)
+	var providers []WeatherProvider
+	for _, pc := range cfg.Providers {
+		if !pc.Enabled {
+			continue
+		}
+		switch pc.Type {
+		case "open-meteo":
+			providers = append(providers, NewOpenMeteoProvider(httpClient))
+		case "openweathermap":
+			providers = append(providers, NewOpenWeatherMapProvider(httpClient, pc.APIKey, pc.CityID, pc.Units))
+		case "met-norway":
+			providers = append(providers, NewMetNoProvider(httpClient, pc.UserAgent))
+		case "nws":
+			providers = append(providers, NewNWSProvider(httpClient, pc.UserAgent))
+		case "world-weather-online":
+			providers = append(providers, NewWorldWeatherOnlineProvider(httpClient, pc.APIKey))
+		case "noaa-metar":
+			providers = append(providers, NewNOAAMetarProvider(httpClient, pc.StationCode))
+		default:
+			return nil, fmt.Errorf("weather_service: unknown provider type %q", pc.Type)
+		}
+	}
+	if len(providers) == 0 {
+		providers = []WeatherProvider{NewOpenMeteoProvider(httpClient)}
+	}
+	return newWeatherService(httpClient, providers), nil
+}
+
+// newCachingHTTPClient wraps an http.Client's transport with CachingTransport
+// so repeated calls to the same upstream URL issue a conditional request
+// (If-None-Match/If-Modified-Since) instead of an unconditional GET once the
+// cached response is stale, as MET Norway's terms of service require.
+func newCachingHTTPClient(timeout time.Duration, cacheStore httpcache.Store) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: httpcache.NewCachingTransport(cacheStore),
+	}
 }
 
-// NewWeatherService creates a weather service using Open-Meteo.
-// The apiKey param is kept for backward compatibility but is unused.
-func NewWeatherService(apiKey string) *WeatherService {
+func newWeatherService(httpClient *http.Client, providers []WeatherProvider) *WeatherService {
 	return &WeatherService{
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+		httpClient: httpClient,
+		providers:  providers,
 		cacheTTL:   5 * time.Minute, // Cache 5 min (Open-Meteo updates every 15 min)
+		health:     make(map[string]ProviderHealth, len(providers)),
 	}
 }
 
+// ProviderHealth returns the last known health of each configured provider,
+// keyed by provider name.
+func (s *WeatherService) ProviderHealth() map[string]ProviderHealth {
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+	out := make(map[string]ProviderHealth, len(s.health))
+	for k, v := range s.health {
+		out[k] = v
+	}
+	return out
+}
+
+func (s *WeatherService) recordHealth(name string, err error) {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	h := s.health[name]
+	h.Name = name
+	if err != nil {
+		h.Healthy = false
+		h.LastError = err.Error()
+	} else {
+		h.Healthy = true
+		h.LastError = ""
+		h.LastSuccess = time.Now()
+	}
+	s.health[name] = h
+}
+
 // --- Open-Meteo response structs ---
 
 type OpenMeteoCurrentResponse struct {
@@ -51,13 +168,19 @@ type OpenMeteoCurrentResponse struct {
 
 type OpenMeteoAirQualityResponse struct {
 	Current struct {
-		Time string   `json:"time"`
-		PM25 *float64 `json:"pm2_5"`
-		PM10 *float64 `json:"pm10"`
+		Time            string   `json:"time"`
+		PM25            *float64 `json:"pm2_5"`
+		PM10            *float64 `json:"pm10"`
+		Ozone           *float64 `json:"ozone"`            // µg/m³
+		NitrogenDioxide *float64 `json:"nitrogen_dioxide"` // µg/m³
+		SulphurDioxide  *float64 `json:"sulphur_dioxide"`  // µg/m³
+		CarbonMonoxide  *float64 `json:"carbon_monoxide"`  // µg/m³
 	} `json:"current"`
 }
 
-// GetCurrentWeather fetches live weather + AQI from Open-Meteo
+// GetCurrentWeather fetches live weather + AQI by walking the configured
+// provider chain, falling back to the next provider on error and recording
+// per-provider health along the way.
 func (s *WeatherService) GetCurrentWeather(ctx context.Context) (domain.Weather, error) {
 	// Check cache first (read lock)
 	s.mu.RLock()
@@ -68,123 +191,165 @@ func (s *WeatherService) GetCurrentWeather(ctx context.Context) (domain.Weather,
 	}
 	s.mu.RUnlock()
 
-	// Upgrade to write lock, double-check to avoid thundering herd
+	// Upgrade to write lock, double-check to avoid thundering herd. Hold the
+	// lock across the fetch itself (not just the cache check) — otherwise
+	// every goroutine that missed the first check falls through and fetches
+	// from upstream in parallel anyway, defeating the point of the recheck.
 	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.cachedData != nil && time.Now().Before(s.cacheExpiry) {
 		cached := *s.cachedData
-		s.mu.Unlock()
 		return cached, nil
 	}
-	s.mu.Unlock()
 
-	// Fetch weather from Open-Meteo
-	weather, err := s.fetchOpenMeteoWeather(ctx)
+	weather, err := s.fetchFromProviders(ctx)
 	if err != nil {
-		log.Printf("Open-Meteo weather error, using fallback: %v", err)
+		log.Printf("All weather providers failed, using fallback: %v", err)
 		return s.getMockWeather(), nil
 	}
 
-	// Fetch AQI from Open-Meteo Air Quality
-	if aqi, err := s.fetchOpenMeteoAQI(ctx); err == nil {
+	if weather.City == "" {
+		weather.City = "Almaty"
+	}
+	if weather.Country == "" {
+		weather.Country = "KZ"
+	}
+
+	// Fetch AQI from Open-Meteo Air Quality (not yet part of the provider chain)
+	aqiStart := time.Now()
+	aqi, dominant, subIndices, aqiErr := s.fetchOpenMeteoAQI(ctx)
+	metrics.ObserveProviderRequest("open-meteo-aqi", aqiErr, time.Since(aqiStart))
+	if aqiErr == nil {
 		weather.AQI = aqi
+		weather.DominantPollutant = dominant
+		weather.PollutantSubIndex = subIndices
 	} else {
-		log.Printf("Open-Meteo AQI error, estimating: %v", err)
+		log.Printf("Open-Meteo AQI error, estimating: %v", aqiErr)
 		weather.AQI = s.estimateAQI(weather.Temperature)
 	}
 
-	// Cache result
-	s.mu.Lock()
+	// Cache result (still under the write lock acquired above)
 	s.cachedData = &weather
 	s.cacheExpiry = time.Now().Add(s.cacheTTL)
-	s.mu.Unlock()
 
-	log.Printf("Open-Meteo weather: %.1f°C, humidity=%d%%, AQI=%d, %s",
-		weather.Temperature, weather.Humidity, weather.AQI, weather.Description)
+	dominantLabel := weather.DominantPollutant
+	if dominantLabel == "" {
+		dominantLabel = "pm25"
+	}
+	metrics.WeatherTemperatureCelsius.WithLabelValues(weather.Source).Set(weather.Temperature)
+	metrics.WeatherAQI.WithLabelValues(dominantLabel).Set(float64(weather.AQI))
+
+	log.Printf("%s weather: %.1f°C, humidity=%d%%, AQI=%d, %s",
+		weather.Source, weather.Temperature, weather.Humidity, weather.AQI, weather.Description)
 
 	return weather, nil
 }
 
-// fetchOpenMeteoWeather queries Open-Meteo Forecast API for current conditions
-func (s *WeatherService) fetchOpenMeteoWeather(ctx context.Context) (domain.Weather, error) {
-	url := fmt.Sprintf(
-		"https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&current=temperature_2m,relative_humidity_2m,apparent_temperature,weather_code,wind_speed_10m,surface_pressure&timezone=Asia%%2FAlmaty",
-		domain.AlmatyCenterLat, domain.AlmatyCenterLon,
-	)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return domain.Weather{}, fmt.Errorf("open-meteo: create request: %w", err)
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return domain.Weather{}, fmt.Errorf("open-meteo: request failed: %w", err)
+// fetchFromProviders tries each configured provider in order, returning the
+// first successful reading. Each failure is logged as it happens; if every
+// provider fails, only the last provider's error is returned.
+func (s *WeatherService) fetchFromProviders(ctx context.Context) (domain.Weather, error) {
+	var lastErr error
+	for _, p := range s.providers {
+		start := time.Now()
+		weather, err := p.Fetch(ctx, domain.AlmatyCenterLat, domain.AlmatyCenterLon)
+		metrics.ObserveProviderRequest(p.Name(), err, time.Since(start))
+		s.recordHealth(p.Name(), err)
+		if err != nil {
+			log.Printf("%s weather provider failed, trying next: %v", p.Name(), err)
+			lastErr = err
+			continue
+		}
+		return weather, nil
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return domain.Weather{}, fmt.Errorf("open-meteo: status %d", resp.StatusCode)
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no weather providers configured")
 	}
+	return domain.Weather{}, lastErr
+}
 
-	var omResp OpenMeteoCurrentResponse
-	if err := json.NewDecoder(resp.Body).Decode(&omResp); err != nil {
-		return domain.Weather{}, fmt.Errorf("open-meteo: decode: %w", err)
+// GetCurrentWeatherFromProvider fetches weather from a single named provider,
+// bypassing the fallback chain and the shared cache — used by the
+// `?provider=` override on GetWeather so operators can inspect one source
+// directly instead of whatever the chain currently prefers.
+func (s *WeatherService) GetCurrentWeatherFromProvider(ctx context.Context, name string) (domain.Weather, error) {
+	for _, p := range s.providers {
+		if p.Name() != name {
+			continue
+		}
+		start := time.Now()
+		weather, err := p.Fetch(ctx, domain.AlmatyCenterLat, domain.AlmatyCenterLon)
+		metrics.ObserveProviderRequest(p.Name(), err, time.Since(start))
+		s.recordHealth(p.Name(), err)
+		return weather, err
 	}
+	return domain.Weather{}, fmt.Errorf("weather_service: provider %q is not configured", name)
+}
 
-	c := omResp.Current
-	description, icon := wmoToDescription(c.WeatherCode)
-
-	return domain.Weather{
-		Temperature: math.Round(c.Temperature2m*10) / 10,
-		FeelsLike:   math.Round(c.ApparentTemp*10) / 10,
-		Humidity:    c.RelativeHumidity2m,
-		Description: description,
-		Icon:        icon,
-		WindSpeed:   math.Round(c.WindSpeed10m/3.6*10) / 10, // km/h → m/s
-		Visibility:  10000,
-		Pressure:    int(math.Round(c.SurfacePressure)),
-		City:        "Almaty",
-		Country:     "KZ",
-		Timestamp:   time.Now(),
-		IsMock:      false,
-	}, nil
+// fetchOpenMeteoWeather queries Open-Meteo Forecast API for current conditions.
+// Kept as a thin wrapper around OpenMeteoProvider for callers that want a
+// single named source regardless of the configured chain (e.g. GetForecast).
+func (s *WeatherService) fetchOpenMeteoWeather(ctx context.Context) (domain.Weather, error) {
+	return NewOpenMeteoProvider(s.httpClient).Fetch(ctx, domain.AlmatyCenterLat, domain.AlmatyCenterLon)
 }
 
-// fetchOpenMeteoAQI queries Open-Meteo Air Quality API
-func (s *WeatherService) fetchOpenMeteoAQI(ctx context.Context) (int, error) {
+// fetchOpenMeteoAQI queries Open-Meteo Air Quality API for every pollutant
+// the full EPA AQI needs (PM2.5, PM10, O3, NO2, SO2, CO) and computes the
+// multi-pollutant AQI via computeEPAAQI.
+func (s *WeatherService) fetchOpenMeteoAQI(ctx context.Context) (aqi int, dominant string, subIndices []domain.PollutantSubIndex, err error) {
 	url := fmt.Sprintf(
-		"https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%.4f&longitude=%.4f&current=pm2_5,pm10&timezone=Asia%%2FAlmaty",
+		"https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%.4f&longitude=%.4f&current=pm2_5,pm10,ozone,nitrogen_dioxide,sulphur_dioxide,carbon_monoxide&timezone=Asia%%2FAlmaty",
 		domain.AlmatyCenterLat, domain.AlmatyCenterLon,
 	)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return 0, err
+		return 0, "", nil, err
 	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return 0, err
+		return 0, "", nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("air-quality: status %d", resp.StatusCode)
+		return 0, "", nil, fmt.Errorf("air-quality: status %d", resp.StatusCode)
 	}
 
 	var aqResp OpenMeteoAirQualityResponse
 	if err := json.NewDecoder(resp.Body).Decode(&aqResp); err != nil {
-		return 0, err
+		return 0, "", nil, err
 	}
 
 	if aqResp.Current.PM25 == nil {
-		return 0, fmt.Errorf("air-quality: PM2.5 is null")
+		return 0, "", nil, fmt.Errorf("air-quality: PM2.5 is null")
+	}
+
+	concentrations := pollutantConcentrations{
+		PM25: aqResp.Current.PM25,
+		PM10: aqResp.Current.PM10,
+	}
+	if aqResp.Current.Ozone != nil {
+		v := ugm3ToPPM(*aqResp.Current.Ozone, molarMassO3)
+		concentrations.O3 = &v
+	}
+	if aqResp.Current.NitrogenDioxide != nil {
+		v := ugm3ToPPB(*aqResp.Current.NitrogenDioxide, molarMassNO2)
+		concentrations.NO2 = &v
+	}
+	if aqResp.Current.SulphurDioxide != nil {
+		v := ugm3ToPPB(*aqResp.Current.SulphurDioxide, molarMassSO2)
+		concentrations.SO2 = &v
+	}
+	if aqResp.Current.CarbonMonoxide != nil {
+		v := ugm3ToPPM(*aqResp.Current.CarbonMonoxide, molarMassCO)
+		concentrations.CO = &v
 	}
 
-	pm25 := *aqResp.Current.PM25
-	aqi := pm25ToAQI(pm25)
-	log.Printf("Open-Meteo AQI: PM2.5=%.1f μg/m³ → EPA AQI=%d", pm25, aqi)
-	return aqi, nil
+	aqi, dominant, subIndices = computeEPAAQI(concentrations)
+	log.Printf("Open-Meteo AQI: PM2.5=%.1f μg/m³ → EPA AQI=%d (dominant=%s)", *aqResp.Current.PM25, aqi, dominant)
+	return aqi, dominant, subIndices, nil
 }
 
 // wmoToDescription converts WMO weather code to description + icon
@@ -213,38 +378,6 @@ func wmoToDescription(code int) (string, string) {
 	}
 }
 
-// pm25ToAQI converts PM2.5 concentration (μg/m³) to US EPA AQI (0-500).
-// Uses the February 2024 revised breakpoints (88 FR 5558).
-// Key change: "Good" category lowered from 12.0 to 9.0 µg/m³,
-// "Very Unhealthy" ceiling lowered from 150.4 to 125.4 µg/m³.
-func pm25ToAQI(pm25 float64) int {
-	type bp struct {
-		cLow, cHigh float64
-		iLow, iHigh int
-	}
-	breakpoints := []bp{
-		{0.0, 9.0, 0, 50},
-		{9.1, 35.4, 51, 100},
-		{35.5, 55.4, 101, 150},
-		{55.5, 125.4, 151, 200},
-		{125.5, 225.4, 201, 300},
-		{225.5, 325.4, 301, 400},
-		{325.5, 500.4, 401, 500},
-	}
-
-	for _, b := range breakpoints {
-		if pm25 >= b.cLow && pm25 <= b.cHigh {
-			aqi := float64(b.iHigh-b.iLow)/(b.cHigh-b.cLow)*(pm25-b.cLow) + float64(b.iLow)
-			return int(math.Round(aqi))
-		}
-	}
-
-	if pm25 > 500.4 {
-		return 500
-	}
-	return 0
-}
-
 // estimateAQI provides a rough AQI estimate when Air Pollution API is unavailable
 func (s *WeatherService) estimateAQI(temp float64) int {
 	month := time.Now().Month()